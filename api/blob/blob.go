@@ -0,0 +1,29 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned if there is no blob with the key
+var ErrNotFound = errors.New("blob not found")
+
+// Store stores binary data, e.g. screenshots and videos, by key. Keys are slash separated paths, so a store can map them
+// to files or to the object keys of an S3-compatible bucket.
+type Store interface {
+	// Stores the data of the reader with the key and returns its size. An existing blob with the key is replaced.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Returns the data of the blob, the caller has to close it. Returns ErrNotFound if there is no blob with the key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Deletes the blob, deleting a blob which does not exist is not an error
+	Delete(ctx context.Context, key string) error
+}
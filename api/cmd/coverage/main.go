@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/TestAndWin/e2e-coverage/coverage/jobs"
 	"github.com/TestAndWin/e2e-coverage/dependency"
 	_ "github.com/TestAndWin/e2e-coverage/docs"
 	"github.com/TestAndWin/e2e-coverage/router"
@@ -34,6 +35,9 @@ func main() {
 		container.CloseConnections()
 	}()
 
+	// Start the background jobs
+	jobs.Start(jobs.CoverageSnapshots(), jobs.ExpiredQuarantines(), jobs.ExpiredAttachments())
+
 	// Start the router
 	router.HandleRequest()
 }
@@ -10,6 +10,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 
 	"github.com/TestAndWin/e2e-coverage/logger"
 	"github.com/spf13/viper"
@@ -20,8 +21,21 @@ type Config struct {
 	DBPassword string `mapstructure:"DB_PASSWORD"`
 	DBHost     string `mapstructure:"DB_HOST"`
 	JWTKey     string `mapstructure:"JWT_KEY"`
+	// Directory of the test attachments, e.g. screenshots and videos
+	AttachmentDir string `mapstructure:"ATTACHMENT_DIR"`
+	// Max. size of a test attachment in MB
+	AttachmentMaxSizeMB int64 `mapstructure:"ATTACHMENT_MAX_SIZE_MB"`
+	// Number of days after which test attachments are deleted
+	AttachmentRetentionDays int `mapstructure:"ATTACHMENT_RETENTION_DAYS"`
 }
 
+// Defaults of the optional config values
+const (
+	defaultAttachmentDir           = "attachments"
+	defaultAttachmentMaxSizeMB     = 50
+	defaultAttachmentRetentionDays = 30
+)
+
 // Returns the config. When the DB_USER is set as env variable, all values will be read from the environment variables.
 // Otherwise the config is read from the config.env file
 func LoadConfig() (config Config, err error) {
@@ -33,6 +47,10 @@ func LoadConfig() (config Config, err error) {
 		c.DBPassword = os.Getenv("DB_PASSWORD")
 		c.DBHost = os.Getenv("DB_HOST")
 		c.JWTKey = os.Getenv("JWT_KEY")
+		c.AttachmentDir = os.Getenv("ATTACHMENT_DIR")
+		c.AttachmentMaxSizeMB, _ = strconv.ParseInt(os.Getenv("ATTACHMENT_MAX_SIZE_MB"), 10, 64)
+		c.AttachmentRetentionDays, _ = strconv.Atoi(os.Getenv("ATTACHMENT_RETENTION_DAYS"))
+		c.setDefaults()
 		return c, nil
 	} else {
 		logger.Debugf("Read config from config.env")
@@ -44,7 +62,21 @@ func LoadConfig() (config Config, err error) {
 			return
 		}
 		err = viper.Unmarshal(&config)
+		config.setDefaults()
 		return
 	}
 
 }
+
+// Sets the optional values which are not configured
+func (c *Config) setDefaults() {
+	if c.AttachmentDir == "" {
+		c.AttachmentDir = defaultAttachmentDir
+	}
+	if c.AttachmentMaxSizeMB <= 0 {
+		c.AttachmentMaxSizeMB = defaultAttachmentMaxSizeMB
+	}
+	if c.AttachmentRetentionDays <= 0 {
+		c.AttachmentRetentionDays = defaultAttachmentRetentionDays
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats are the counters of the cache since the start of the application
+type Stats struct {
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	HitRate       float64 `json:"hit-rate"`
+	Invalidations int64   `json:"invalidations"`
+	Products      int     `json:"products"`
+	Entries       int     `json:"entries"`
+}
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+// Cache stores computed coverage per product. All entries of a product are removed together, when the test results,
+// areas or features of the product change. Entries also expire after the TTL, as the coverage window moves with time.
+type Cache struct {
+	mu            sync.Mutex
+	ttl           time.Duration
+	products      map[string]map[string]entry
+	hits          int64
+	misses        int64
+	invalidations int64
+}
+
+// New creates an empty cache whose entries expire after the TTL
+func New(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, products: make(map[string]map[string]entry)}
+}
+
+// Get returns the value stored for the key of the product
+func (c *Cache) Get(productId string, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.products[productId][key]
+	if ok && time.Now().After(e.expires) {
+		delete(c.products[productId], key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return e.value, true
+}
+
+// Version changes with every invalidation. It is read before a value is computed and passed to Set.
+func (c *Cache) Version() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.invalidations
+}
+
+// Set stores the value for the key of the product. The value is not stored, if there has been an invalidation since
+// the version was read, as the value might have been computed from the data before the change.
+func (c *Cache) Set(productId string, key string, version int64, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.invalidations {
+		return
+	}
+	entries, ok := c.products[productId]
+	if !ok {
+		entries = make(map[string]entry)
+		c.products[productId] = entries
+	}
+	entries[key] = entry{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+// Invalidate removes all entries of the product
+func (c *Cache) Invalidate(productId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.products, productId)
+	c.invalidations++
+}
+
+// InvalidateAll removes the entries of all products. It is used, when the product of a change is not known.
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.products = make(map[string]map[string]entry)
+	c.invalidations++
+}
+
+// Stats returns the counters and the number of stored entries, including expired ones
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := Stats{Hits: c.hits, Misses: c.misses, Invalidations: c.invalidations, Products: len(c.products)}
+	if total := c.hits + c.misses; total > 0 {
+		s.HitRate = float64(c.hits) / float64(total)
+	}
+	for _, entries := range c.products {
+		s.Entries += len(entries)
+	}
+	return s
+}
@@ -46,6 +46,7 @@ func AddArea(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to insert area: %w", err)))
 		return
 	}
+	invalidateCoverage(strconv.FormatInt(a.ProductId, 10))
 
 	a.Id = id
 	response.Created(c, a)
@@ -132,6 +133,7 @@ func UpdateArea(c *gin.Context) {
 		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Area with ID %d", id)))
 		return
 	}
+	invalidateCoverage(areaProduct(repo, c.Param("id")))
 
 	response.ResponseWithDataAndMessage(c, http.StatusOK, a, "Area updated successfully")
 }
@@ -162,6 +164,9 @@ func DeleteArea(c *gin.Context) {
 		return
 	}
 
+	// The product is needed after the area has been deleted
+	pid := areaProduct(repo, idStr)
+
 	// First, delete all exploratory tests for this area
 	_, err = repo.DeleteExplTestsByAreaId(idStr)
 	if err != nil {
@@ -179,11 +184,12 @@ func DeleteArea(c *gin.Context) {
 	// Delete all tests for each feature in this area
 	for _, feature := range features {
 		featureId := strconv.FormatInt(feature.Id, 10)
-		_, err := repo.DeleteTestsByFeatureId(featureId)
+		_, attachments, err := repo.DeleteTestsByFeatureId(featureId)
 		if err != nil {
 			errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to delete tests for feature %d: %w", feature.Id, err)))
 			return
 		}
+		deleteAttachmentContents(c.Request.Context(), attachments)
 	}
 
 	// Delete all features in this area
@@ -199,6 +205,7 @@ func DeleteArea(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to delete area %d: %w", id, err)))
 		return
 	}
+	invalidateCoverage(pid)
 
 	// Since we've already deleted associated data, we know the area existed
 	// Even if affected=0, consider this a success
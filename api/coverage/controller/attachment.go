@@ -0,0 +1,310 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/TestAndWin/e2e-coverage/blob"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/logger"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Max. number of files uploaded with one request
+const maxAttachmentsPerUpload = 10
+
+// Max. memory used to parse a multipart upload, larger files are buffered in temporary files
+const attachmentMemory = 32 << 20
+
+// UploadAttachments godoc
+// @Summary      Upload attachments of a test result
+// @Description  Upload files of a test result, e.g. screenshots and videos of a failed test, as multipart form with one or more
+// @Description  "file" fields. The size of a file is limited by ATTACHMENT_MAX_SIZE_MB, attachments are deleted after
+// @Description  ATTACHMENT_RETENTION_DAYS.
+// @Tags         attachment
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      int   true  "Test ID"
+// @Param        file  formData  file  true  "Attachment"
+// @Success      201  {array}   model.Attachment
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      413  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/tests/{id}/attachments [POST]
+func UploadAttachments(c *gin.Context) {
+	maxSize := getConfig().AttachmentMaxSizeMB << 20
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize*maxAttachmentsPerUpload+attachmentMemory)
+	if err := c.Request.ParseMultipartForm(attachmentMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if goerrors.As(err, &tooLarge) {
+			errors.HandleError(c, attachmentTooLarge(maxSize))
+			return
+		}
+		errors.HandleError(c, errors.NewBadRequestError("Error parsing multipart form", err))
+		return
+	}
+	defer c.Request.MultipartForm.RemoveAll()
+	files := c.Request.MultipartForm.File["file"]
+	if len(files) == 0 || len(files) > maxAttachmentsPerUpload {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid attachments",
+			fmt.Errorf("between 1 and %d files are required", maxAttachmentsPerUpload)))
+		return
+	}
+	for _, fh := range files {
+		if fh.Size > maxSize {
+			errors.HandleError(c, attachmentTooLarge(maxSize))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	store, err := getBlobStore()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	pid, err := repo.GetTestProductId(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Test with ID %s", c.Param("id"))))
+		return
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	testId, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	// Either all files of the upload are stored or none, the contents stored so far are deleted if one file fails
+	attachments := []model.Attachment{}
+	for _, fh := range files {
+		a := model.Attachment{ProductId: pid, TestId: testId, Name: attachmentName(fh.Filename), CreatedBy: actor(c)}
+		err := storeAttachment(c, store, fh, &a)
+		if a.BlobKey != "" {
+			attachments = append(attachments, a)
+		}
+		if err != nil {
+			deleteAttachmentContents(c.Request.Context(), attachments)
+			errors.HandleError(c, errors.NewInternalError(err))
+			return
+		}
+	}
+	err = repo.WithTx(func(tx *repository.CoverageStore) error {
+		for i := range attachments {
+			id, err := tx.InsertAttachment(attachments[i])
+			if err != nil {
+				return err
+			}
+			attachments[i].Id = id
+		}
+		return nil
+	})
+	if err != nil {
+		deleteAttachmentContents(c.Request.Context(), attachments)
+		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to insert attachment: %w", err)))
+		return
+	}
+	response.Created(c, attachments)
+}
+
+// Stores the file in the blob store. The content type is detected from the content, as the one sent can not be trusted.
+func storeAttachment(c *gin.Context, store blob.Store, fh *multipart.FileHeader, a *model.Attachment) error {
+	f, err := fh.Open()
+	if err != nil {
+		return fmt.Errorf("error opening attachment %s: %w", a.Name, err)
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("error reading attachment %s: %w", a.Name, err)
+	}
+	a.ContentType = http.DetectContentType(head[:n])
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return err
+	}
+	a.BlobKey = fmt.Sprintf("%d/%d/%s", a.ProductId, a.TestId, hex.EncodeToString(random))
+	a.Size, err = store.Put(c.Request.Context(), a.BlobKey, io.MultiReader(bytes.NewReader(head[:n]), f))
+	return err
+}
+
+// GetTestAttachments godoc
+// @Summary      Get the attachments of a test result
+// @Description  Get the attachments of the test result, without their content
+// @Tags         attachment
+// @Produce      json
+// @Param        id    path    int     true  "Test ID"
+// @Success      200  {array}  model.Attachment
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/tests/{id}/attachments [GET]
+func GetTestAttachments(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	attachments, err := repo.GetTestAttachments(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, attachments)
+}
+
+// DownloadAttachment godoc
+// @Summary      Download an attachment
+// @Description  Download the content of the attachment. Images and videos are shown in the browser, other files are downloaded.
+// @Tags         attachment
+// @Produce      octet-stream
+// @Param        id    path    int     true  "Attachment ID"
+// @Success      200  {file}    file
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/attachments/{id} [GET]
+func DownloadAttachment(c *gin.Context) {
+	a, ok := attachment(c)
+	if !ok {
+		return
+	}
+	store, err := getBlobStore()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	content, err := store.Get(c.Request.Context(), a.BlobKey)
+	if err == blob.ErrNotFound {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Content of attachment with ID %s", c.Param("id"))))
+		return
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	defer content.Close()
+
+	// Only images and videos are shown inline, an uploaded HTML page must not run in the context of the application
+	disposition := "attachment"
+	if (strings.HasPrefix(a.ContentType, "image/") || strings.HasPrefix(a.ContentType, "video/")) && !strings.Contains(a.ContentType, "svg") {
+		disposition = "inline"
+	}
+	c.DataFromReader(http.StatusOK, a.Size, a.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": a.Name}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment godoc
+// @Summary      Delete an attachment
+// @Description  Delete the attachment and its content
+// @Tags         attachment
+// @Produce      json
+// @Param        id    path      int     true  "Attachment ID"
+// @Success      204  {string}  SuccessResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/attachments/{id} [DELETE]
+func DeleteAttachment(c *gin.Context) {
+	a, ok := attachment(c)
+	if !ok {
+		return
+	}
+	store, err := getBlobStore()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if err := store.Delete(c.Request.Context(), a.BlobKey); err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if _, err := repo.DeleteAttachment(c.Param("id")); err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.NoContent(c)
+}
+
+// Deletes the content of attachments which have been deleted in DB or were never inserted. The deletion is not undone if
+// a content cannot be deleted, so the error is only logged. It is also done if the client has closed the connection.
+func deleteAttachmentContents(ctx context.Context, attachments []model.Attachment) {
+	ctx = context.WithoutCancel(ctx)
+	if len(attachments) == 0 {
+		return
+	}
+	store, err := getBlobStore()
+	if err != nil {
+		logger.Errorf("Error getting blob store: %v", err)
+		return
+	}
+	for _, a := range attachments {
+		if err := store.Delete(ctx, a.BlobKey); err != nil {
+			logger.Errorf("Error deleting content of attachment %d: %v", a.Id, err)
+		}
+	}
+}
+
+// Returns the attachment of the request, the error is handled if it cannot be found
+func attachment(c *gin.Context) (model.Attachment, bool) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return model.Attachment{}, false
+	}
+	a, err := repo.GetAttachment(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Attachment with ID %s", c.Param("id"))))
+		return a, false
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return a, false
+	}
+	return a, true
+}
+
+// Name of the uploaded file without its path
+func attachmentName(filename string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	// The column holds 255 characters, the end of the name is kept for its extension
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+	return name
+}
+
+func attachmentTooLarge(maxSize int64) error {
+	return errors.NewAppError(fmt.Errorf("an attachment must not be larger than %d MB", maxSize>>20),
+		"Attachment too large", "ATTACHMENT_TOO_LARGE", http.StatusRequestEntityTooLarge)
+}
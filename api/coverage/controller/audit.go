@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/auth"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/logger"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Default and maximum number of audit log entries returned
+const (
+	defaultAuditEntries = 100
+	maxAuditEntries     = 1000
+)
+
+// GetAuditLog godoc
+// @Summary      Get the audit log of a product
+// @Description  Get the latest entries of the audit log of the product, the latest first
+// @Tags         audit
+// @Produce      json
+// @Param        id     path   int  true   "Product ID"
+// @Param        limit  query  int  false  "Number of entries, default 100"
+// @Success      200  {array}   model.AuditEntry
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/audit-log [GET]
+func GetAuditLog(c *gin.Context) {
+	limit := uint64(defaultAuditEntries)
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.ParseUint(value, 10, 64); err != nil || limit == 0 || limit > maxAuditEntries {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid limit",
+				fmt.Errorf("limit must be between 1 and %d", maxAuditEntries)))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	entries, err := repo.GetAuditLog(c.Param("id"), limit)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, entries)
+}
+
+// Returns the email of the signed in user, or the ID of the user of the API key
+func actor(c *gin.Context) string {
+	if email := c.GetString(auth.ContextUserEmail); email != "" {
+		return email
+	}
+	if id, ok := c.Get(auth.ContextUserID); ok {
+		return fmt.Sprintf("user %v", id)
+	}
+	return ""
+}
+
+// Adds the change done by the user of the request to the audit log. The change is done already, so a failure is only logged.
+func audit(c *gin.Context, e model.AuditEntry) {
+	e.Actor = actor(c)
+	repo, err := getRepository()
+	if err == nil {
+		_, err = repo.InsertAuditEntry(e)
+	}
+	if err != nil {
+		logger.Errorf("Error adding %s of %s %d to the audit log: %v", e.Action, e.Entity, e.EntityId, err)
+	}
+}
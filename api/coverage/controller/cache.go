@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/logger"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCoverageCacheStats godoc
+// @Summary      Get the statistics of the coverage cache
+// @Description  Get the hits, misses and invalidations of the coverage cache since the start of the application
+// @Tags         coverage
+// @Produce      json
+// @Success      200  {object}  cache.Stats
+// @Router       /api/v1/coverage/cache-stats [GET]
+func GetCoverageCacheStats(c *gin.Context) {
+	response.OK(c, getCoverageCache().Stats())
+}
+
+// Key of a cached coverage response, the path of the request with its sorted query parameters
+func coverageCacheKey(c *gin.Context) string {
+	return c.Request.URL.Path + "?" + c.Request.URL.Query().Encode()
+}
+
+// Returns the ID of the product of the area, an empty string if the area is not known
+func areaProduct(repo *repository.CoverageStore, areaId string) string {
+	return productOf(repo.GetAreaProductId, areaId)
+}
+
+// Returns the ID of the product of the feature, an empty string if the feature is not known
+func featureProduct(repo *repository.CoverageStore, featureId string) string {
+	return productOf(repo.GetFeatureProductId, featureId)
+}
+
+func productOf(productId func(string) (int64, error), id string) string {
+	pid, err := productId(id)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatInt(pid, 10)
+}
+
+// Removes the cached coverage of the product, after its test results, areas or features were changed.
+// Without product ID the coverage of all products is removed, it is used if the product of a change is not known.
+func invalidateCoverage(productId string) {
+	if productId == "" {
+		logger.Debugf("Invalidating cached coverage of all products")
+		getCoverageCache().InvalidateAll()
+		return
+	}
+	logger.Debugf("Invalidating cached coverage of product %s", productId)
+	getCoverageCache().Invalidate(productId)
+}
@@ -9,6 +9,9 @@ LICENSE file in the root directory of this source tree.
 package controller
 
 import (
+	"github.com/TestAndWin/e2e-coverage/blob"
+	"github.com/TestAndWin/e2e-coverage/config"
+	"github.com/TestAndWin/e2e-coverage/coverage/cache"
 	"github.com/TestAndWin/e2e-coverage/coverage/repository"
 	"github.com/TestAndWin/e2e-coverage/dependency"
 )
@@ -22,3 +25,18 @@ func getRepository() (*repository.CoverageStore, error) {
 	}
 	return repo, nil
 }
+
+// getCoverageCache returns the coverage cache from the dependency container
+func getCoverageCache() *cache.Cache {
+	return dependency.GetContainer().GetCoverageCache()
+}
+
+// getBlobStore returns the store of the test attachments from the dependency container
+func getBlobStore() (blob.Store, error) {
+	return dependency.GetContainer().GetBlobStore()
+}
+
+// getConfig returns the application configuration from the dependency container
+func getConfig() *config.Config {
+	return dependency.GetContainer().GetConfig()
+}
@@ -9,25 +9,47 @@ LICENSE file in the root directory of this source tree.
 package controller
 
 import (
+	"database/sql"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
 	"github.com/TestAndWin/e2e-coverage/errors"
 	"github.com/TestAndWin/e2e-coverage/response"
 	"github.com/gin-gonic/gin"
 )
 
+// Page size of the test list, if only the page is requested
+const defaultTestPageSize = 50
+
+// Maximum page size of the test list
+const maxTestPageSize = 500
+
 // GetAreaCoverage godoc
 // @Summary		   Get coverage for all product areas.
-// @Description  Get coverage for all product areas. Only tests from the last 28 days are considered.
+// @Description  Get coverage for all product areas. Only tests of the coverage window are considered.
 // @Tags         coverage
 // @Produce      json
 // @Param        product    path      int     true  "Product ID"
+// @Param        branch       query     string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query     string  false  "Environment"
+// @Param        days         query     int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query     string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query     string  false  "End of the coverage window (date or RFC 3339)"
 // @Success      200  {array}  model.Area
 // @Failure      400  {string}  ErrorResponse
 // @Router       /api/v1/coverage/{id}/areas [GET]
 func GetAreaCoverage(c *gin.Context) {
 	pId := c.Param("id")
+	key := coverageCacheKey(c)
+	if cached, ok := getCoverageCache().Get(pId, key); ok {
+		respondAreaCoverage(c, cached.([]model.Area))
+		return
+	}
+	version := getCoverageCache().Version()
+
 	repo, err := getRepository()
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
@@ -39,17 +61,26 @@ func GetAreaCoverage(c *gin.Context) {
 		return
 	}
 
-	tests, err := repo.GetAreaCoverageForProduct(pId)
+	filter, ok := coverageFilter(c, pId, repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	tests, err := repo.GetAreaCoverageForProduct(pId, filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Unable to get area coverage for product", err))
 		return
 	}
 
-	areasCoverage, err := processAreaCoverage(areas, tests)
+	areasCoverage, err := processAreaCoverage(pId, areas, tests, filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Unable to process area coverage", err))
 		return
 	}
+	getCoverageCache().Set(pId, key, version, areasCoverage)
+	respondAreaCoverage(c, areasCoverage)
+}
+
+func respondAreaCoverage(c *gin.Context, areasCoverage []model.Area) {
 	// Return consistent response format
 	if len(areasCoverage) == 0 {
 		response.EmptyList(c)
@@ -58,11 +89,95 @@ func GetAreaCoverage(c *gin.Context) {
 	}
 }
 
-func processAreaCoverage(areas []model.Area, tests map[int64]model.Test) ([]model.Area, error) {
+// Returns the time window, branch and environment filter of the request. Without parameters, the coverage window and the
+// default branch of the product are used. An empty branch parameter returns the test results of all branches.
+// The window is either set with days or with from and to (date or RFC 3339 time, to is inclusive for a date). With only
+// to, the window has the default length and ends at to.
+func coverageFilter(c *gin.Context, id string, defaultFilter func(string) (repository.CoverageFilter, error)) (repository.CoverageFilter, bool) {
+	var filter repository.CoverageFilter
+	if defaultFilter != nil {
+		var err error
+		filter, err = defaultFilter(id)
+		if err != nil && err != sql.ErrNoRows {
+			errors.HandleError(c, errors.NewInternalError(fmt.Errorf("error getting coverage settings: %w", err)))
+			return filter, false
+		}
+	}
+	if branch, ok := c.GetQuery("branch"); ok {
+		filter.Branch = branch
+		filter.IncludeEmptyBranch = false
+	}
+	filter.Environment = c.Query("environment")
+
+	days, from, to := c.Query("days"), c.Query("from"), c.Query("to")
+	if days != "" && (from != "" || to != "") {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid coverage window", fmt.Errorf("days cannot be combined with from and to")))
+		return filter, false
+	}
+	if days != "" {
+		d, err := strconv.ParseInt(days, 10, 64)
+		if err != nil || d <= 0 {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid days", fmt.Errorf("days must be a positive number")))
+			return filter, false
+		}
+		window := repository.DefaultCoverageFilter(d)
+		filter.From, filter.To = window.From, window.To
+	}
+	if from != "" || to != "" {
+		length := time.Duration(repository.DefaultCoverageDays) * 24 * time.Hour
+		if !filter.From.IsZero() {
+			length = time.Since(filter.From)
+		}
+		var err error
+		if filter.From, err = parseWindowTime(from, false); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid from", err))
+			return filter, false
+		}
+		if filter.To, err = parseWindowTime(to, true); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid to", err))
+			return filter, false
+		}
+		if from == "" {
+			filter.From = filter.To.Add(-length)
+		}
+		if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid coverage window", fmt.Errorf("from must be before to")))
+			return filter, false
+		}
+	}
+	return filter, true
+}
+
+// Coverage window for test results which are not queried for a product
+func defaultCoverageWindow(string) (repository.CoverageFilter, error) {
+	return repository.DefaultCoverageFilter(repository.DefaultCoverageDays), nil
+}
+
+// Parses a date or an RFC 3339 time. The end of the window is exclusive, so a date as end includes the whole day.
+func parseWindowTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		if end {
+			return t.AddDate(0, 0, 1), nil
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func processAreaCoverage(pid string, areas []model.Area, tests map[int64]model.Test, filter repository.CoverageFilter) ([]model.Area, error) {
 	repo, err := getRepository()
 	if err != nil {
 		return nil, err
 	}
+	// Expl. tests of all areas
+	explTests, err := repo.GetExplTestOverviewForProduct(pid, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching exploratory tests for product %s: %w", pid, err)
+	}
+
 	areasCoverage := []model.Area{}
 	for _, a := range areas {
 		// Iterate through all areas and check if there is coverage data for that area
@@ -73,14 +188,13 @@ func processAreaCoverage(areas []model.Area, tests map[int64]model.Test) ([]mode
 			a.Failures = t.Failures
 			a.Skipped = t.Skipped
 			a.FirstTotal = t.FirstTotal
+			a.Quarantined = t.Quarantined
+			a.RetryPasses = t.RetryPasses
 		}
 		// Add expl. tests
-		explTest, err := repo.GetExplTestOverviewForArea(a.Id)
-		if err != nil {
-			return nil, fmt.Errorf("error fetching exploratory tests for area %d: %w", a.Id, err)
-		} else {
-			a.ExplTests = explTest.ExplTests
-			a.ExplRating = explTest.ExplRating
+		if et, ok := explTests[a.Id]; ok {
+			a.ExplTests = et.ExplTests
+			a.ExplRating = et.ExplRating
 		}
 		areasCoverage = append(areasCoverage, a)
 	}
@@ -89,10 +203,15 @@ func processAreaCoverage(areas []model.Area, tests map[int64]model.Test) ([]mode
 
 // GetFeatureCoverage godoc
 // @Summary      Get coverage for all area features.
-// @Description  Get coverage for all area features. Only tests from the last 28 days are considered.
+// @Description  Get coverage for all area features. Only tests of the coverage window are considered.
 // @Tags         coverage
 // @Produce      json
 // @Param        product    path      int     true  "Area ID"
+// @Param        branch       query     string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query     string  false  "Environment"
+// @Param        days         query     int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query     string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query     string  false  "End of the coverage window (date or RFC 3339)"
 // @Success      200  {array}  model.Feature
 // @Failure      400  {string}  ErrorResponse
 // @Router       /api/v1/coverage/areas/{id}/features [get]
@@ -102,13 +221,29 @@ func GetFeatureCoverage(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	// The coverage is cached for the product of the area, the coverage of an unknown area is not cached
+	pId := areaProduct(repo, c.Param("id"))
+	cacheable := pId != ""
+	key := coverageCacheKey(c)
+	if cacheable {
+		if cached, ok := getCoverageCache().Get(pId, key); ok {
+			response.OK(c, cached)
+			return
+		}
+	}
+	version := getCoverageCache().Version()
+
 	features, err := repo.GetAllAreaFeatures(c.Param("id"))
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Error getting area features", err))
 		return
 	}
 
-	tests, err := repo.GetFeatureCoverageForArea(c.Param("id"))
+	filter, ok := coverageFilter(c, c.Param("id"), repo.AreaCoverageFilter)
+	if !ok {
+		return
+	}
+	tests, err := repo.GetFeatureCoverageForArea(c.Param("id"), filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Error getting feature coverage", err))
 		return
@@ -123,18 +258,28 @@ func GetFeatureCoverage(c *gin.Context) {
 			f.Failures = t.Failures
 			f.Skipped = t.Skipped
 			f.FirstTotal = t.FirstTotal
+			f.Quarantined = t.Quarantined
+			f.RetryPasses = t.RetryPasses
 		}
 		featuresCoverage = append(featuresCoverage, f)
 	}
+	if cacheable {
+		getCoverageCache().Set(pId, key, version, featuresCoverage)
+	}
 	response.OK(c, featuresCoverage)
 }
 
 // GetTestsCoverage godoc
 // @Summary      Get coverage for all tests of a feature.
-// @Description  Get coverage for all tests of a feature for the last 28 days.
+// @Description  Get coverage for all tests of a feature in the coverage window.
 // @Tags         coverage
 // @Produce      json
 // @Param        id    path      int     true  "Feature ID"
+// @Param        branch       query     string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query     string  false  "Environment"
+// @Param        days         query     int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query     string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query     string  false  "End of the coverage window (date or RFC 3339)"
 // @Success      200  {array}  model.Test
 // @Failure      400  {string}  ErrorResponse
 // @Router       /coverage/features/:id/tests [get]
@@ -144,35 +289,175 @@ func GetTestsCoverage(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
-	t, err := repo.GetAllFeatureTests(c.Param("id"))
+	// The coverage is cached for the product of the feature, the coverage of an unknown feature is not cached
+	pId := featureProduct(repo, c.Param("id"))
+	cacheable := pId != ""
+	key := coverageCacheKey(c)
+	if cacheable {
+		if cached, ok := getCoverageCache().Get(pId, key); ok {
+			response.OK(c, cached)
+			return
+		}
+	}
+	version := getCoverageCache().Version()
+
+	filter, ok := coverageFilter(c, c.Param("id"), repo.FeatureCoverageFilter)
+	if !ok {
+		return
+	}
+	t, err := repo.GetAllFeatureTests(c.Param("id"), filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Error getting feature tests", err))
 		return
 	}
+	if cacheable {
+		getCoverageCache().Set(pId, key, version, t)
+	}
 	response.OK(c, t)
 }
 
 // GetProductTestsCoverage godoc
 // @Summary      Get coverage for all tests of a product.
-// @Description  Get coverage for all tests of a product for the last 28 days.
+// @Description  Get coverage for all tests of a product in the coverage window. The list is paged, if page or page-size is set.
 // @Tags         coverage
 // @Produce      json
 // @Param        id    path      int     true  "Product ID"
+// @Param        branch       query     string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query     string  false  "Environment"
+// @Param        days         query     int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query     string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query     string  false  "End of the coverage window (date or RFC 3339)"
+// @Param        page         query     int     false  "Page, starting with 1"
+// @Param        page-size    query     int     false  "Number of tests per page, default 50, max 500"
+// @Param        component    query     string  false  "Component"
+// @Param        area-id      query     int     false  "Area ID"
+// @Param        feature-id   query     int     false  "Feature ID"
+// @Param        unassigned   query     bool    false  "Only tests without area and feature"
+// @Param        status       query     string  false  "failing, passing or flaky"
+// @Param        search       query     string  false  "Part of the suite or file name"
+// @Param        sort         query     string  false  "name, last-run or failure-rate, default is component, suite and file"
+// @Param        order        query     string  false  "asc or desc, default asc for name and desc otherwise"
 // @Success      200  {array}  model.Test
 // @Failure      400  {string}  ErrorResponse
 // @Router       /coverage/products/:id/tests [get]
 func GetProductTestsCoverage(c *gin.Context) {
+	q, page, pageSize, ok := testQuery(c)
+	if !ok {
+		return
+	}
+
+	pId := c.Param("id")
+	key := coverageCacheKey(c)
+	if cached, ok := getCoverageCache().Get(pId, key); ok {
+		respondTestList(c, cached.(testList), page, pageSize)
+		return
+	}
+	version := getCoverageCache().Version()
+
 	repo, err := getRepository()
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
-	t, err := repo.GetAllProductTests(c.Param("id"))
+	filter, ok := coverageFilter(c, pId, repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	t, count, err := repo.GetProductTests(pId, filter, q)
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Error getting product tests", err))
 		return
 	}
-	response.OK(c, t)
+	list := testList{tests: t, count: count, paged: q.Limit > 0}
+	getCoverageCache().Set(pId, key, version, list)
+	respondTestList(c, list, page, pageSize)
+}
+
+// Tests of a product and the number of all tests matching the query
+type testList struct {
+	tests []model.Test
+	count int64
+	paged bool
+}
+
+func respondTestList(c *gin.Context, list testList, page int, pageSize int) {
+	if !list.paged {
+		response.OK(c, list.tests)
+		return
+	}
+	if list.tests == nil {
+		list.tests = []model.Test{}
+	}
+	response.PaginatedList(c, list.tests, list.count, page, pageSize)
+}
+
+// Reads the filters, the sort order and the page of a test list. The list is paged only if page or page-size is set.
+func testQuery(c *gin.Context) (repository.TestQuery, int, int, bool) {
+	q := repository.TestQuery{Component: c.Query("component"), Search: c.Query("search")}
+
+	var err error
+	if q.AreaId, err = optionalId(c, "area-id"); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid area ID", err))
+		return q, 0, 0, false
+	}
+	if q.FeatureId, err = optionalId(c, "feature-id"); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid feature ID", err))
+		return q, 0, 0, false
+	}
+	if unassigned := c.Query("unassigned"); unassigned != "" {
+		if q.Unassigned, err = strconv.ParseBool(unassigned); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid unassigned", err))
+			return q, 0, 0, false
+		}
+	}
+
+	switch q.Status = c.Query("status"); q.Status {
+	case "", repository.TestStatusFailing, repository.TestStatusPassing, repository.TestStatusFlaky:
+	default:
+		errors.HandleError(c, errors.NewBadRequestError("Invalid status", fmt.Errorf("status must be one of %s, %s or %s",
+			repository.TestStatusFailing, repository.TestStatusPassing, repository.TestStatusFlaky)))
+		return q, 0, 0, false
+	}
+	switch q.Sort = c.Query("sort"); q.Sort {
+	case "", repository.TestSortName:
+	case repository.TestSortLastRun, repository.TestSortFailureRate:
+		q.Desc = true
+	default:
+		errors.HandleError(c, errors.NewBadRequestError("Invalid sort", fmt.Errorf("sort must be one of %s, %s or %s",
+			repository.TestSortName, repository.TestSortLastRun, repository.TestSortFailureRate)))
+		return q, 0, 0, false
+	}
+	switch c.Query("order") {
+	case "":
+	case "asc":
+		q.Desc = false
+	case "desc":
+		q.Desc = true
+	default:
+		errors.HandleError(c, errors.NewBadRequestError("Invalid order", fmt.Errorf("order must be asc or desc")))
+		return q, 0, 0, false
+	}
+
+	pageValue, pageSizeValue := c.Query("page"), c.Query("page-size")
+	if pageValue == "" && pageSizeValue == "" {
+		return q, 0, 0, true
+	}
+	page, pageSize := 1, defaultTestPageSize
+	if pageValue != "" {
+		if page, err = strconv.Atoi(pageValue); err != nil || page < 1 {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid page", fmt.Errorf("page must be a positive number")))
+			return q, 0, 0, false
+		}
+	}
+	if pageSizeValue != "" {
+		if pageSize, err = strconv.Atoi(pageSizeValue); err != nil || pageSize < 1 || pageSize > maxTestPageSize {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid page size", fmt.Errorf("page size must be between 1 and %d", maxTestPageSize)))
+			return q, 0, 0, false
+		}
+	}
+	q.Limit = uint64(pageSize)
+	q.Offset = uint64(page-1) * uint64(pageSize)
+	return q, page, pageSize, true
 }
 
 // GetComponents godoc
@@ -180,6 +465,11 @@ func GetProductTestsCoverage(c *gin.Context) {
 // @Description  Get all components with their latest test run
 // @Tags         coverage
 // @Produce      json
+// @Param        branch       query     string  false  "Branch"
+// @Param        environment  query     string  false  "Environment"
+// @Param        days         query     int     false  "Number of days, default are all test runs"
+// @Param        from         query     string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query     string  false  "End of the coverage window (date or RFC 3339)"
 // @Success      200  {array}  model.Test
 // @Failure      400  {string}  ErrorResponse
 // @Router       /coverage/components [get]
@@ -189,7 +479,11 @@ func GetComponents(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
-	t, err := repo.GetComponents()
+	filter, ok := coverageFilter(c, "", nil)
+	if !ok {
+		return
+	}
+	t, err := repo.GetComponents(filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewBadRequestError("Error getting components", err))
 		return
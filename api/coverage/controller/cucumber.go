@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadCucumberReport godoc
+// @Summary      Add test results of a Cucumber JSON report
+// @Description  Add test results of a Cucumber JSON report. Every Gherkin feature is stored as one test result, area and feature are taken from the @area: and @feature: tags or the feature name.
+// @Tags         cucumber
+// @Produce      json
+// @Param        id            path      int     true   "Product ID"
+// @Param        apiKey        header    string  true   "Api Key"
+// @Param        testReportUrl header    string  false  "Url of the detail test report"
+// @Param        component     header    string  false  "Component name"
+// @Param        test          body      string  true   "Cucumber JSON"
+// @Success      201  {object} model.UploadResponse
+// @Failure      400  {string}  ErrorResponse
+// @Router       /coverage/:id/upload-cucumber-report [POST]
+func UploadCucumberReport(c *gin.Context) {
+	uploadReport(c, reporter.CucumberFormat)
+}
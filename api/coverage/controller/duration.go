@@ -0,0 +1,305 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Default and maximum number of slow suites returned
+const (
+	defaultSlowSuites = 20
+	maxSlowSuites     = 200
+)
+
+// Default number of days of the current and of the baseline window of the duration regressions
+const (
+	defaultRegressionDays         = 7
+	defaultRegressionBaselineDays = 28
+)
+
+// Min. number of passed results in both windows, so a single slow run is not reported as regression
+const minRegressionRuns = 3
+
+// GetSlowSuites godoc
+// @Summary      Get the slowest suites of a product
+// @Description  Get the suites of the product with the highest median duration in the coverage window, the durations are in ms
+// @Tags         test
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        component    query  string  false  "Component, default are all components"
+// @Param        limit        query  int     false  "Number of suites, default 20"
+// @Param        branch       query  string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query  string  false  "Environment"
+// @Param        days         query  int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query  string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query  string  false  "End of the coverage window (date or RFC 3339)"
+// @Success      200  {array}   model.SuiteDuration
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/slow-suites [GET]
+func GetSlowSuites(c *gin.Context) {
+	limit := defaultSlowSuites
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxSlowSuites {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid limit",
+				fmt.Errorf("limit must be between 1 and %d", maxSlowSuites)))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	filter, ok := coverageFilter(c, c.Param("id"), repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	suites, err := repo.GetSuiteDurations(c.Param("id"), c.Query("component"), filter)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+
+	slow := []model.SuiteDuration{}
+	for _, s := range suites {
+		slow = append(slow, suiteDuration(s))
+	}
+	slices.SortFunc(slow, func(a, b model.SuiteDuration) int {
+		if a.Median != b.Median {
+			return int(b.Median - a.Median)
+		}
+		return strings.Compare(a.Suite+a.FileName, b.Suite+b.FileName)
+	})
+	if len(slow) > limit {
+		slow = slow[:limit]
+	}
+	response.OK(c, slow)
+}
+
+// GetDurationTrend godoc
+// @Summary      Get the duration trend of a test
+// @Description  Get the durations of the results of a suite in the coverage window, the oldest first. With a title the
+// @Description  durations of the test case with this full title are returned. The durations are in ms.
+// @Tags         test
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        component    query  string  true   "Component name"
+// @Param        suite        query  string  true   "Suite name"
+// @Param        file-name    query  string  true   "File name"
+// @Param        title        query  string  false  "Full title of a test case"
+// @Param        branch       query  string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query  string  false  "Environment"
+// @Param        days         query  int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query  string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query  string  false  "End of the coverage window (date or RFC 3339)"
+// @Success      200  {array}   model.DurationPoint
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/tests/durations [GET]
+func GetDurationTrend(c *gin.Context) {
+	component := c.Query("component")
+	suite := c.Query("suite")
+	file := strings.Replace(c.Query("file-name"), "\\\\", "\\", -1)
+	if suite == "" || file == "" {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test", fmt.Errorf("suite and file-name are required")))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	filter, ok := coverageFilter(c, c.Param("id"), repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	if title := c.Query("title"); title != "" {
+		points, err := repo.GetTestCaseDurations(c.Param("id"), component, suite, file, title, filter)
+		if err != nil {
+			errors.HandleError(c, errors.NewInternalError(err))
+			return
+		}
+		response.OK(c, points)
+		return
+	}
+
+	runs, err := repo.GetTestHistory(c.Param("id"), component, suite, file, filter)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	points := []model.DurationPoint{}
+	for _, r := range runs {
+		points = append(points, model.DurationPoint{TestId: r.TestId, TestRun: r.TestRun, Duration: r.Duration, Status: r.Status})
+	}
+	response.OK(c, points)
+}
+
+// GetDurationRegressions godoc
+// @Summary      Get the suites which became slower
+// @Description  Compares the median duration of the passed results of every suite in the window with the one of the baseline
+// @Description  window before it. Suites whose median grew by more than the duration regression percentage of the product are
+// @Description  returned, the highest increase first. Both windows need at least 3 passed results of a suite.
+// @Tags         test
+// @Produce      json
+// @Param        id             path   int     true   "Product ID"
+// @Param        component      query  string  false  "Component, default are all components"
+// @Param        branch         query  string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment    query  string  false  "Environment"
+// @Param        days           query  int     false  "Number of days of the window, default 7"
+// @Param        from           query  string  false  "Start of the window (date or RFC 3339)"
+// @Param        to             query  string  false  "End of the window (date or RFC 3339)"
+// @Param        baseline-days  query  int     false  "Number of days of the baseline window, default 28"
+// @Success      200  {array}   model.DurationRegression
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/duration-regressions [GET]
+func GetDurationRegressions(c *gin.Context) {
+	baselineDays := defaultRegressionBaselineDays
+	if value := c.Query("baseline-days"); value != "" {
+		var err error
+		if baselineDays, err = strconv.Atoi(value); err != nil || baselineDays <= 0 {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid baseline-days", fmt.Errorf("baseline-days must be a positive number")))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	pid := c.Param("id")
+	settings, err := repo.GetProductSettings(pid)
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Product with ID %s", pid)))
+		return
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	current, ok := coverageFilter(c, pid, func(id string) (repository.CoverageFilter, error) {
+		filter, err := repo.ProductCoverageFilter(id)
+		window := repository.DefaultCoverageFilter(defaultRegressionDays)
+		filter.From, filter.To = window.From, window.To
+		return filter, err
+	})
+	if !ok {
+		return
+	}
+	if current.From.IsZero() {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid window", fmt.Errorf("the window needs a start")))
+		return
+	}
+	baseline := current
+	baseline.From, baseline.To = current.From.AddDate(0, 0, -baselineDays), current.From
+
+	currentSuites, err := repo.GetSuiteDurations(pid, c.Query("component"), current)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	baselineSuites, err := repo.GetSuiteDurations(pid, c.Query("component"), baseline)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, durationRegressions(baselineSuites, currentSuites, settings.DurationRegressionPct))
+}
+
+// Returns the suites whose median duration of the passed results grew by more than the percentage, the highest increase first
+func durationRegressions(baseline []repository.SuiteDurations, current []repository.SuiteDurations, pct int64) []model.DurationRegression {
+	baselineRuns := map[string][]int64{}
+	for _, s := range baseline {
+		baselineRuns[suiteKey(s)] = passedDurations(s.Runs)
+	}
+
+	regressions := []model.DurationRegression{}
+	for _, s := range current {
+		before, now := baselineRuns[suiteKey(s)], passedDurations(s.Runs)
+		if len(before) < minRegressionRuns || len(now) < minRegressionRuns {
+			continue
+		}
+		r := model.DurationRegression{Component: s.Component, Suite: s.Suite, FileName: s.FileName,
+			BaselineRuns: int64(len(before)), BaselineMedian: median(before), CurrentRuns: int64(len(now)), CurrentMedian: median(now)}
+		r.IncreasePct = float64(r.CurrentMedian-r.BaselineMedian) * 100 / float64(r.BaselineMedian)
+		if r.IncreasePct > float64(pct) {
+			regressions = append(regressions, r)
+		}
+	}
+	slices.SortFunc(regressions, func(a, b model.DurationRegression) int {
+		switch {
+		case a.IncreasePct > b.IncreasePct:
+			return -1
+		case a.IncreasePct < b.IncreasePct:
+			return 1
+		default:
+			return strings.Compare(a.Suite+a.FileName, b.Suite+b.FileName)
+		}
+	})
+	return regressions
+}
+
+func suiteDuration(s repository.SuiteDurations) model.SuiteDuration {
+	d := model.SuiteDuration{Component: s.Component, Suite: s.Suite, FileName: s.FileName, Runs: int64(len(s.Runs))}
+	durations := make([]int64, 0, len(s.Runs))
+	var sum int64
+	for _, r := range s.Runs {
+		durations = append(durations, r.Duration)
+		sum += r.Duration
+		d.Max = max(d.Max, r.Duration)
+	}
+	if n := len(s.Runs); n > 0 {
+		d.Median = median(durations)
+		d.Mean = sum / int64(n)
+		d.Latest, d.LastRun = s.Runs[n-1].Duration, s.Runs[n-1].TestRun
+	}
+	return d
+}
+
+// Failed results are not comparable, e.g. a timeout makes a suite slower and an early failure faster
+func passedDurations(runs []model.DurationPoint) []int64 {
+	var durations []int64
+	for _, r := range runs {
+		if r.Status == model.TestPassed {
+			durations = append(durations, r.Duration)
+		}
+	}
+	return durations
+}
+
+func median(values []int64) int64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func suiteKey(s repository.SuiteDurations) string {
+	return s.Component + "\x1f" + s.Suite + "\x1f" + s.FileName
+}
@@ -10,6 +10,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/TestAndWin/e2e-coverage/coverage/model"
 	"github.com/TestAndWin/e2e-coverage/errors"
@@ -46,6 +47,7 @@ func AddExplTest(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	invalidateCoverage(areaProduct(repo, strconv.FormatInt(et.AreaId, 10)))
 
 	et.Id = id
 	response.Created(c, et)
@@ -66,20 +68,26 @@ func DeleteExplTest(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	// The product is needed after the expl. test has been deleted
+	pid := productOf(repo.GetExplTestProductId, c.Param("id"))
 	_, err = repo.DeleteExplTest(c.Param("id"))
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	invalidateCoverage(pid)
 	c.Status(http.StatusNoContent)
 }
 
 // GetExplTestsForArea godoc
 // @Summary      Get all exploratory tests.
-// @Description  Get all exploratory tests for the specified area for the last 28 days
+// @Description  Get all exploratory tests for the specified area in the coverage window of the product
 // @Tags         expl-test
 // @Produce      json
 // @Param        areaid    path      int     true  "Area ID"
+// @Param        days      query     int     false  "Number of days of the coverage window"
+// @Param        from      query     string  false  "Start of the coverage window"
+// @Param        to        query     string  false  "End of the coverage window"
 // @Success      200  {array}  model.ExplTest
 // @Failure      500  {string}  ErrorResponse
 // @Router       /api/v1/expl-tests/area/{areaid} [POST]
@@ -89,7 +97,11 @@ func GetExplTestsForArea(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
-	et, err := repo.GetExplTests(c.Param("areaid"))
+	filter, ok := coverageFilter(c, c.Param("areaid"), repo.AreaCoverageFilter)
+	if !ok {
+		return
+	}
+	et, err := repo.GetExplTests(c.Param("areaid"), filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Default and maximum number of failure clusters returned
+const (
+	defaultFailureClusters = 20
+	maxFailureClusters     = 200
+)
+
+// GetFailureClusters godoc
+// @Summary      Get the failure clusters of a product
+// @Description  Get the failed test cases of the latest result of every test in the coverage window, grouped by their failure
+// @Description  signature. The signature is built from the failure message and the top of the stack outside of the test files,
+// @Description  without numbers, ids and timestamps, so failures with the same root cause are one cluster. The clusters with the
+// @Description  most failures are first.
+// @Tags         coverage
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        branch       query  string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query  string  false  "Environment"
+// @Param        days         query  int     false  "Number of days of the coverage window, default is the coverage window of the product"
+// @Param        from         query  string  false  "Start of the coverage window (date or RFC 3339)"
+// @Param        to           query  string  false  "End of the coverage window (date or RFC 3339)"
+// @Param        limit        query  int     false  "Number of clusters, default 20"
+// @Success      200  {array}   model.FailureCluster
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/failure-clusters [GET]
+func GetFailureClusters(c *gin.Context) {
+	limit := defaultFailureClusters
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxFailureClusters {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid limit",
+				fmt.Errorf("limit must be between 1 and %d", maxFailureClusters)))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	filter, ok := coverageFilter(c, c.Param("id"), repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	clusters, err := repo.GetFailureClusters(c.Param("id"), filter, limit)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, clusters)
+}
@@ -44,6 +44,7 @@ func AddFeature(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	invalidateCoverage(areaProduct(repo, strconv.FormatInt(f.AreaId, 10)))
 	f.Id = id
 	response.Created(c, f)
 }
@@ -110,12 +111,13 @@ func UpdateFeature(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	invalidateCoverage(featureProduct(repo, c.Param("id")))
 	response.OK(c, f)
 }
 
 // DeleteFeature godoc
 // @Summary      Delete the product feature
-// @Description  Delete the product feature
+// @Description  Delete the product feature together with its tests and their attachments
 // @Tags         feature
 // @Produce      json
 // @Param        id    path      int     true  "Feature ID"
@@ -130,12 +132,16 @@ func DeleteFeature(c *gin.Context) {
 		return
 	}
 
-	// First delete all tests associated with this feature
-	_, err = repo.DeleteTestsByFeatureId(featureId)
+	// The product is needed after the feature has been deleted
+	pid := featureProduct(repo, featureId)
+
+	// First delete all tests associated with this feature, then the content of their attachments
+	_, attachments, err := repo.DeleteTestsByFeatureId(featureId)
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	deleteAttachmentContents(c.Request.Context(), attachments)
 
 	// Then delete the feature itself
 	_, err = repo.DeleteFeature(featureId)
@@ -143,5 +149,6 @@ func DeleteFeature(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	invalidateCoverage(pid)
 	c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Default and maximum number of flaky tests returned
+const (
+	defaultFlakyTests = 20
+	maxFlakyTests     = 200
+)
+
+// GetFlakyTests godoc
+// @Summary      Get the flaky tests of a product
+// @Description  Get the flaky tests of the product, the highest flakiness score first. A test is flaky, if its status flipped
+// @Description  between two results of the same commit or more than once within the latest results on the default branch.
+// @Description  The number of results is the flaky window of the product. The flips of these results are returned as well.
+// @Tags         test
+// @Produce      json
+// @Param        id     path   int  true   "Product ID"
+// @Param        limit  query  int  false  "Number of tests, default 20"
+// @Success      200  {array}   model.TestFlakiness
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/flaky [GET]
+func GetFlakyTests(c *gin.Context) {
+	limit := uint64(defaultFlakyTests)
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.ParseUint(value, 10, 64); err != nil || limit == 0 || limit > maxFlakyTests {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid limit",
+				fmt.Errorf("limit must be between 1 and %d", maxFlakyTests)))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	pid := c.Param("id")
+	settings, err := repo.GetProductSettings(pid)
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Product with ID %s", pid)))
+		return
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+
+	tests, err := repo.GetFlakyTests(pid, settings, limit)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, tests)
+}
@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadJUnitReport godoc
+// @Summary      Add test results of a JUnit XML report
+// @Description  Add test results of a JUnit XML report. Every testsuite element is stored as one test result.
+// @Tags         junit
+// @Accept       xml
+// @Produce      json
+// @Param        id            path      int     true   "Product ID"
+// @Param        apiKey        header    string  true   "Api Key"
+// @Param        testReportUrl header    string  false  "Url of the detail test report"
+// @Param        component     header    string  false  "Component name"
+// @Param        test          body      string  true   "JUnit XML"
+// @Success      201  {object} model.UploadResponse
+// @Failure      400  {string}  ErrorResponse
+// @Router       /coverage/:id/upload-junit-report [POST]
+func UploadJUnitReport(c *gin.Context) {
+	uploadReport(c, reporter.JUnitFormat)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// A mapping rule with its pattern compiled to a regular expression
+type compiledMappingRule struct {
+	rule model.MappingRule
+	re   *regexp.Regexp
+}
+
+// AddMappingRule godoc
+// @Summary      Add a new mapping rule to a product
+// @Description  Takes a mapping rule JSON and stores it in DB. Return saved JSON.
+// @Tags         mapping-rule
+// @Produce      json
+// @Param        rule  body     model.MappingRule  true  "Mapping rule JSON"
+// @Success      201  {object}  model.MappingRule
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/mapping-rules [POST]
+func AddMappingRule(c *gin.Context) {
+	var r model.MappingRule
+	if err := c.BindJSON(&r); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding mapping rule JSON", err))
+		return
+	}
+	if _, err := compileMappingRule(r); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid mapping rule", err))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	id, err := repo.InsertMappingRule(r)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to insert mapping rule: %w", err)))
+		return
+	}
+
+	r.Id = id
+	response.Created(c, r)
+}
+
+// GetProductMappingRules godoc
+// @Summary      Get all mapping rules of a product
+// @Description  Get all mapping rules of the specified product, ordered by priority
+// @Tags         mapping-rule
+// @Produce      json
+// @Param        id    path    int     true  "Product ID"
+// @Success      200  {array}  model.MappingRule
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/mapping-rules [GET]
+func GetProductMappingRules(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	rules, err := repo.GetMappingRules(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, rules)
+}
+
+// UpdateMappingRule godoc
+// @Summary      Update a mapping rule
+// @Description  Takes a mapping rule JSON and the rule ID and updates the rule in the DB.
+// @Tags         mapping-rule
+// @Produce      json
+// @Param        id    path     int                true  "Mapping rule ID"
+// @Param        rule  body     model.MappingRule  true  "Mapping rule JSON"
+// @Success      200  {object}  model.MappingRule
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/mapping-rules/{id} [PUT]
+func UpdateMappingRule(c *gin.Context) {
+	var r model.MappingRule
+	if err := c.BindJSON(&r); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding mapping rule JSON", err))
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid mapping rule ID", err))
+		return
+	}
+	r.Id = id
+	if _, err := compileMappingRule(r); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid mapping rule", err))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	affected, err := repo.UpdateMappingRule(r)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to update mapping rule %d: %w", id, err)))
+		return
+	}
+	if affected == 0 {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Mapping rule with ID %d", id)))
+		return
+	}
+
+	response.ResponseWithDataAndMessage(c, http.StatusOK, r, "Mapping rule updated successfully")
+}
+
+// DeleteMappingRule godoc
+// @Summary      Delete a mapping rule
+// @Description  Delete a mapping rule
+// @Tags         mapping-rule
+// @Produce      json
+// @Param        id    path      int     true  "Mapping rule ID"
+// @Success      204  {string}  SuccessResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/mapping-rules/{id} [DELETE]
+func DeleteMappingRule(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	_, err = repo.DeleteMappingRule(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.NoContent(c)
+}
+
+// Checks the mapping rule and compiles its pattern
+func compileMappingRule(r model.MappingRule) (compiledMappingRule, error) {
+	if r.Pattern == "" || r.Area == "" || r.Feature == "" {
+		return compiledMappingRule{}, fmt.Errorf("pattern, area and feature are required")
+	}
+
+	var expr string
+	switch r.Type {
+	case model.MappingRuleTitle, model.MappingRuleTag:
+		expr = r.Pattern
+	case model.MappingRuleFile:
+		expr = globToRegexp(r.Pattern)
+	default:
+		return compiledMappingRule{}, fmt.Errorf("unknown rule type %s, must be one of %s, %s or %s",
+			r.Type, model.MappingRuleTitle, model.MappingRuleFile, model.MappingRuleTag)
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return compiledMappingRule{}, fmt.Errorf("invalid pattern %s: %w", r.Pattern, err)
+	}
+	return compiledMappingRule{rule: r, re: re}, nil
+}
+
+// Converts a glob pattern to an anchored regular expression. ** matches any number of directories,
+// * and ? match within one path segment. Every wildcard is a group, so it can be referenced with $1, $2, ...
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString("(.*)")
+			i++
+		case glob[i] == '*':
+			b.WriteString("([^/]*)")
+		case glob[i] == '?':
+			b.WriteString("([^/])")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// Compiles all mapping rules of the product. Invalid rules are skipped, they cannot be stored anyway.
+func loadMappingRules(pid string) ([]compiledMappingRule, error) {
+	repo, err := getRepository()
+	if err != nil {
+		return nil, err
+	}
+	rules, err := repo.GetMappingRules(pid)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mapping rules: %w", err)
+	}
+
+	var compiled []compiledMappingRule
+	for _, r := range rules {
+		if cr, err := compileMappingRule(r); err == nil {
+			compiled = append(compiled, cr)
+		}
+	}
+	return compiled, nil
+}
+
+// Sets area and feature of a test result using the first matching rule. Rules are only applied to test results
+// without area or feature, an {area}|{feature}|{suite} title always wins.
+func applyMappingRules(tr reporter.TestResult, rules []compiledMappingRule) reporter.TestResult {
+	if tr.Area != "" && tr.Feature != "" {
+		return tr
+	}
+	for _, cr := range rules {
+		if area, feature, ok := cr.match(tr); ok {
+			tr.Area = area
+			tr.Feature = feature
+			return tr
+		}
+	}
+	return tr
+}
+
+// Returns area and feature if the rule matches the test result
+func (cr compiledMappingRule) match(tr reporter.TestResult) (string, string, bool) {
+	var subjects []string
+	switch cr.rule.Type {
+	case model.MappingRuleTitle:
+		subjects = []string{tr.Suite}
+	case model.MappingRuleFile:
+		subjects = []string{strings.ReplaceAll(tr.File, "\\", "/")}
+	case model.MappingRuleTag:
+		subjects = tr.Tags
+	}
+
+	for _, subject := range subjects {
+		m := cr.re.FindStringSubmatchIndex(subject)
+		if m == nil {
+			continue
+		}
+		area := string(cr.re.ExpandString(nil, cr.rule.Area, subject, m))
+		feature := string(cr.re.ExpandString(nil, cr.rule.Feature, subject, m))
+		if area != "" && feature != "" {
+			return area, feature, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadPlaywrightReport godoc
+// @Summary      Add test results of a Playwright JSON report
+// @Description  Add test results of a Playwright JSON report. Every project is stored as a separate component.
+// @Tags         playwright
+// @Produce      json
+// @Param        id            path      int     true   "Product ID"
+// @Param        apiKey        header    string  true   "Api Key"
+// @Param        testReportUrl header    string  false  "Url of the detail test report"
+// @Param        component     header    string  false  "Component name, the project name is appended"
+// @Param        test          body      string  true   "Playwright JSON"
+// @Success      201  {object} model.UploadResponse
+// @Failure      400  {string}  ErrorResponse
+// @Router       /coverage/:id/upload-playwright-report [POST]
+func UploadPlaywrightReport(c *gin.Context) {
+	uploadReport(c, reporter.PlaywrightFormat)
+}
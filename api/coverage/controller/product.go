@@ -9,10 +9,13 @@ LICENSE file in the root directory of this source tree.
 package controller
 
 import (
+	"database/sql"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
 	"github.com/TestAndWin/e2e-coverage/errors"
 	"github.com/TestAndWin/e2e-coverage/response"
 	"github.com/gin-gonic/gin"
@@ -105,7 +108,8 @@ func UpdateProduct(c *gin.Context) {
 
 // DeleteProduct godoc
 // @Summary      Delete the product
-// @Description  Delete the product
+// @Description  Delete the product together with its test results, attachments, runs and audit log. A product with areas
+// @Description  cannot be deleted.
 // @Tags         product
 // @Produce      json
 // @Param        id    path      int     true  "Product ID"
@@ -118,10 +122,113 @@ func DeleteProduct(c *gin.Context) {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
-	_, err = repo.DeleteProduct(c.Param("id"))
+	_, attachments, err := repo.DeleteProduct(c.Param("id"))
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	invalidateCoverage(c.Param("id"))
+	deleteAttachmentContents(c.Request.Context(), attachments)
 	c.Status(http.StatusNoContent)
 }
+
+// GetProductSettings godoc
+// @Summary      Get the settings of a product
+// @Description  Get the settings of a product, e.g. the strict mapping mode
+// @Tags         product
+// @Produce      json
+// @Param        id    path      int     true  "Product ID"
+// @Success      200  {object}  model.ProductSettings
+// @Failure      404  {string}  ErrorResponse
+// @Failure      500  {string}  ErrorResponse
+// @Router       /api/v1/products/{id}/settings [GET]
+func GetProductSettings(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	ps, err := repo.GetProductSettings(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Product with ID %s", c.Param("id"))))
+		return
+	}
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, ps)
+}
+
+// UpdateProductSettings godoc
+// @Summary      Update the settings of a product
+// @Description  Takes a product settings JSON and the product ID and updates the settings in DB. Settings which are
+// @Description  not part of the JSON are not changed.
+// @Tags         product
+// @Param        id        path      int                    true  "Product ID"
+// @Param        settings  body      model.ProductSettings  true  "Product settings JSON"
+// @Produce      json
+// @Success      200  {object}  model.ProductSettings
+// @Failure      400  {string}  ErrorResponse
+// @Failure      404  {string}  ErrorResponse
+// @Failure      500  {string}  ErrorResponse
+// @Router       /api/v1/products/{id}/settings [PUT]
+func UpdateProductSettings(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid product ID", err))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	// The JSON is bound to the current settings, so omitted settings keep their value
+	ps, err := repo.GetProductSettings(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Product with ID %s", c.Param("id"))))
+		return
+	}
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if err := c.BindJSON(&ps); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding JSON", err))
+		return
+	}
+	ps.ProductId = id
+	if ps.CoverageDays < 0 {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid coverage days", fmt.Errorf("coverage days must not be negative")))
+		return
+	}
+	if ps.CoverageDays == 0 {
+		ps.CoverageDays = repository.DefaultCoverageDays
+	}
+	if ps.FlakyWindow < 0 || ps.FlakyWindow == 1 || ps.FlakyWindow > repository.MaxFlakyWindow {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid flaky window",
+			fmt.Errorf("flaky window must be between 2 and %d", repository.MaxFlakyWindow)))
+		return
+	}
+	if ps.FlakyWindow == 0 {
+		ps.FlakyWindow = repository.DefaultFlakyWindow
+	}
+	if ps.DurationRegressionPct < 0 {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid duration regression",
+			fmt.Errorf("duration regression percentage must not be negative")))
+		return
+	}
+	if ps.DurationRegressionPct == 0 {
+		ps.DurationRegressionPct = repository.DefaultDurationRegressionPct
+	}
+
+	if _, err = repo.UpdateProductSettings(ps); err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	// The coverage window and the default branch change the coverage
+	invalidateCoverage(c.Param("id"))
+	response.OK(c, ps)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// AddTestQuarantine godoc
+// @Summary      Quarantine a test
+// @Description  Takes a test quarantine JSON with the component, suite and file of the test, a reason, an owner and the expiry.
+// @Description  Until the quarantine expires, the failures of the test are not part of the failures of its area and feature,
+// @Description  they are reported as quarantined. Quarantining a quarantined test again replaces its reason, owner and expiry.
+// @Tags         test-quarantine
+// @Produce      json
+// @Param        id          path     int                   true  "Product ID"
+// @Param        quarantine  body     model.TestQuarantine  true  "Test quarantine JSON"
+// @Success      201  {object}  model.TestQuarantine
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/quarantines [POST]
+func AddTestQuarantine(c *gin.Context) {
+	var q model.TestQuarantine
+	if err := c.BindJSON(&q); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding test quarantine JSON", err))
+		return
+	}
+	pid, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid product ID", err))
+		return
+	}
+	q.ProductId = pid
+	if q.Suite == "" || q.FileName == "" {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test quarantine", fmt.Errorf("suite and file-name are required")))
+		return
+	}
+	if q.Reason == "" || q.Owner == "" {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test quarantine", fmt.Errorf("reason and owner are required")))
+		return
+	}
+	if !q.ExpiresAt.After(time.Now()) {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test quarantine", fmt.Errorf("expires-at must be in the future")))
+		return
+	}
+	q.CreatedBy = actor(c)
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	q, err = repo.InsertTestQuarantine(q)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to insert test quarantine: %w", err)))
+		return
+	}
+	audit(c, model.AuditEntry{ProductId: pid, Action: model.AuditQuarantineAdded, Entity: model.AuditEntityQuarantine, EntityId: q.Id,
+		Details: fmt.Sprintf("%s: reason %q, owner %s, expires at %s", quarantineTest(q), q.Reason, q.Owner, q.ExpiresAt.Format(time.RFC3339))})
+	invalidateCoverage(c.Param("id"))
+	response.Created(c, q)
+}
+
+// GetProductTestQuarantines godoc
+// @Summary      Get the quarantined tests of a product
+// @Description  Get the quarantines of the product which have not expired, the ones expiring first first
+// @Tags         test-quarantine
+// @Produce      json
+// @Param        id    path    int     true  "Product ID"
+// @Success      200  {array}  model.TestQuarantine
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/quarantines [GET]
+func GetProductTestQuarantines(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	quarantines, err := repo.GetTestQuarantines(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, quarantines)
+}
+
+// DeleteTestQuarantine godoc
+// @Summary      Release a test from quarantine
+// @Description  Delete a test quarantine, the failures of the test are part of the failures of its area and feature again
+// @Tags         test-quarantine
+// @Produce      json
+// @Param        id    path      int     true  "Test quarantine ID"
+// @Success      204  {string}  SuccessResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/quarantines/{id} [DELETE]
+func DeleteTestQuarantine(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	q, err := repo.GetTestQuarantine(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Test quarantine with ID %s", c.Param("id"))))
+		return
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if _, err := repo.DeleteTestQuarantine(c.Param("id")); err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	audit(c, model.AuditEntry{ProductId: q.ProductId, Action: model.AuditQuarantineRemoved, Entity: model.AuditEntityQuarantine,
+		EntityId: q.Id, Details: quarantineTest(q)})
+	invalidateCoverage(strconv.FormatInt(q.ProductId, 10))
+	response.NoContent(c)
+}
+
+// Describes the quarantined test in the audit log
+func quarantineTest(q model.TestQuarantine) string {
+	return fmt.Sprintf("component %q, suite %q, file %q", q.Component, q.Suite, q.FileName)
+}
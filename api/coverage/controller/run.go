@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultRunLimit = 100
+
+// GetProductRuns godoc
+// @Summary      Get the latest runs of a product
+// @Description  Get the latest runs of a product with their CI metadata and the sum of their test results
+// @Tags         run
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        limit        query  int     false  "Maximum number of runs, default 100"
+// @Param        branch       query  string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query  string  false  "Environment"
+// @Param        days         query  int     false  "Number of days, default is the coverage window of the product"
+// @Param        from         query  string  false  "Start of the window (date or RFC 3339)"
+// @Param        to           query  string  false  "End of the window (date or RFC 3339)"
+// @Success      200  {array}  model.Run
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/runs [GET]
+func GetProductRuns(c *gin.Context) {
+	limit, err := strconv.ParseUint(c.DefaultQuery("limit", strconv.Itoa(defaultRunLimit)), 10, 64)
+	if err != nil || limit == 0 {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid limit", err))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	filter, ok := coverageFilter(c, c.Param("id"), repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	runs, err := repo.GetProductRuns(c.Param("id"), filter, limit)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, runs)
+}
+
+// GetRun godoc
+// @Summary      Get a run
+// @Description  Get a run with its CI metadata and the sum of its test results
+// @Tags         run
+// @Produce      json
+// @Param        id    path      int     true  "Run ID"
+// @Success      200  {object}  model.Run
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/runs/{id} [GET]
+func GetRun(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	run, err := repo.GetRun(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Run with ID %s", c.Param("id"))))
+		return
+	}
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, run)
+}
+
+// GetRunTests godoc
+// @Summary      Get all test results of a run
+// @Description  Get all test results uploaded for the run
+// @Tags         run
+// @Produce      json
+// @Param        id    path    int     true  "Run ID"
+// @Success      200  {array}  model.Test
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/runs/{id}/tests [GET]
+func GetRunTests(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	tests, err := repo.GetRunTests(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, tests)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Default number of days of the coverage trend
+const defaultTrendDays = 90
+
+// Maximum number of days which can be snapshotted with one request
+const maxSnapshotDays = 366
+
+// GetCoverageTrend godoc
+// @Summary      Get the coverage trend of a product, an area or a feature
+// @Description  Get the daily coverage snapshots of a product, an area or a feature. A snapshot is the coverage of the
+// @Description  coverage window and the default branch of the product at the end of the day (UTC).
+// @Tags         coverage
+// @Produce      json
+// @Param        id          path   int     true   "Product ID"
+// @Param        area-id     query  int     false  "Area ID, for the trend of an area"
+// @Param        feature-id  query  int     false  "Feature ID, for the trend of a feature"
+// @Param        days        query  int     false  "Number of days, default 90"
+// @Param        from        query  string  false  "First day (date or RFC 3339)"
+// @Param        to          query  string  false  "Last day (date or RFC 3339)"
+// @Success      200  {array}  model.CoverageSnapshot
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/{id}/trend [GET]
+func GetCoverageTrend(c *gin.Context) {
+	areaId, err := optionalId(c, "area-id")
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid area ID", err))
+		return
+	}
+	featureId, err := optionalId(c, "feature-id")
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid feature ID", err))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	filter, ok := coverageFilter(c, c.Param("id"), defaultTrendWindow)
+	if !ok {
+		return
+	}
+	snapshots, err := repo.GetCoverageTrend(c.Param("id"), areaId, featureId, filter)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, snapshots)
+}
+
+// TakeCoverageSnapshots godoc
+// @Summary      Take the coverage snapshots of a product
+// @Description  Takes the coverage snapshots of the product for every day from the specified day until today.
+// @Description  Snapshots are taken every hour in the background, this is needed only to fill the trend of past days.
+// @Tags         coverage
+// @Produce      json
+// @Param        id    path   int     true   "Product ID"
+// @Param        from  query  string  false  "First day (date), default is today"
+// @Success      200  {object}  response.StandardResponse
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/{id}/snapshots [POST]
+func TakeCoverageSnapshots(c *gin.Context) {
+	today := time.Now().UTC()
+	from := today
+	if value := c.Query("from"); value != "" {
+		var err error
+		if from, err = time.Parse(time.DateOnly, value); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid from", err))
+			return
+		}
+	}
+	days := int(today.Sub(from).Hours()/24) + 1
+	if from.After(today) || days > maxSnapshotDays {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid from",
+			fmt.Errorf("from must be a day of the last %d days", maxSnapshotDays)))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	pid := c.Param("id")
+	if _, err := repo.GetProductSettings(pid); err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Product with ID %s", pid)))
+		return
+	} else if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+
+	for i := 0; i < days; i++ {
+		if err := repo.TakeCoverageSnapshot(pid, from.AddDate(0, 0, i)); err != nil {
+			errors.HandleError(c, errors.NewInternalError(err))
+			return
+		}
+	}
+	response.ResponseWithDataAndMessage(c, http.StatusOK, gin.H{"days": days}, "Coverage snapshots taken successfully")
+}
+
+// Coverage trend of the last days
+func defaultTrendWindow(string) (repository.CoverageFilter, error) {
+	return repository.DefaultCoverageFilter(defaultTrendDays), nil
+}
+
+// Returns the ID of the query parameter or 0 if it is not set
+func optionalId(c *gin.Context, name string) (int64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
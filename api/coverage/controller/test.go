@@ -19,7 +19,7 @@ import (
 
 // DeleteTests godoc
 // @Summary      Delete all tests for the specified component, suite and file-name
-// @Description  Delete all tests for the specified component, suite and file-name
+// @Description  Delete all tests for the specified component, suite and file-name together with their attachments
 // @Tags         test
 // @Produce      json
 // @Param        component      query      string     true  "Component name"
@@ -38,11 +38,14 @@ func DeleteTests(c *gin.Context) {
 	component := c.Query("component")
 	file := strings.Replace(c.Query("file-name"), "\\\\", "\\", -1)
 
-	_, err = repo.DeleteTest(component, suite, file)
+	_, attachments, err := repo.DeleteTest(component, suite, file)
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
+	// The tests are deleted in all products
+	invalidateCoverage("")
+	deleteAttachmentContents(c.Request.Context(), attachments)
 	c.Status(http.StatusNoContent)
 }
 
@@ -54,6 +57,9 @@ func DeleteTests(c *gin.Context) {
 // @Param        component      query      string     true  "Component name"
 // @Param        suite          query      string     true  "Suite name"
 // @Param        file-name      query      string     true  "File name"
+// @Param        days           query      int        false "Number of days, default 28"
+// @Param        from           query      string     false "Start of the window"
+// @Param        to             query      string     false "End of the window"
 // @Success      200 {array}  model.Test
 // @Success      500 {string} ErrorResponse
 // @Router       /api/v1/tests [GET]
@@ -67,10 +73,37 @@ func GetAllTestForSuiteFile(c *gin.Context) {
 	component := c.Query("component")
 	file := strings.Replace(c.Query("file-name"), "\\\\", "\\", -1)
 
-	tests, err := repo.GetAllTestForSuiteFile(component, suite, file)
+	filter, ok := coverageFilter(c, "", defaultCoverageWindow)
+	if !ok {
+		return
+	}
+	tests, err := repo.GetAllTestForSuiteFile(component, suite, file, filter)
 	if err != nil {
 		errors.HandleError(c, errors.NewInternalError(err))
 		return
 	}
 	response.OK(c, tests)
 }
+
+// GetTestCases godoc
+// @Summary      Get all test cases of a test.
+// @Description  Get all test cases of the uploaded test result with their state, duration and error.
+// @Tags         test
+// @Produce      json
+// @Param        id    path      int     true  "Test ID"
+// @Success      200 {array}  model.TestCase
+// @Success      500 {string} ErrorResponse
+// @Router       /api/v1/coverage/tests/{id}/cases [GET]
+func GetTestCases(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	cases, err := repo.GetTestCases(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, cases)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// AddTestAlias godoc
+// @Summary      Declare that a test was renamed or moved
+// @Description  Takes a test alias JSON with the old and new suite and file. The test results of the old suite and file are then part of the history and coverage of the new one.
+// @Tags         test-alias
+// @Produce      json
+// @Param        id     path     int              true  "Product ID"
+// @Param        alias  body     model.TestAlias  true  "Test alias JSON"
+// @Success      201  {object}  model.TestAlias
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/test-aliases [POST]
+func AddTestAlias(c *gin.Context) {
+	var a model.TestAlias
+	if err := c.BindJSON(&a); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding test alias JSON", err))
+		return
+	}
+	pid, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid product ID", err))
+		return
+	}
+	a.ProductId = pid
+	if a.OldSuite == "" || a.NewSuite == "" {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test alias", fmt.Errorf("old and new suite are required")))
+		return
+	}
+	if a.OldSuite == a.NewSuite && a.OldFile == a.NewFile {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test alias", fmt.Errorf("old and new suite and file are equal")))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	a, err = repo.InsertTestAlias(a)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to insert test alias: %w", err)))
+		return
+	}
+	invalidateCoverage(c.Param("id"))
+	response.Created(c, a)
+}
+
+// GetProductTestAliases godoc
+// @Summary      Get all test aliases of a product
+// @Description  Get all test aliases of the specified product
+// @Tags         test-alias
+// @Produce      json
+// @Param        id    path    int     true  "Product ID"
+// @Success      200  {array}  model.TestAlias
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/test-aliases [GET]
+func GetProductTestAliases(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	aliases, err := repo.GetTestAliases(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, aliases)
+}
+
+// DeleteTestAlias godoc
+// @Summary      Delete a test alias
+// @Description  Delete a test alias, the old suite and file are a separate test again
+// @Tags         test-alias
+// @Produce      json
+// @Param        id    path      int     true  "Test alias ID"
+// @Success      204  {string}  SuccessResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/test-aliases/{id} [DELETE]
+func DeleteTestAlias(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	affected, err := repo.DeleteTestAlias(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if affected == 0 {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Test alias with ID %s", c.Param("id"))))
+		return
+	}
+	// The product of the alias is not known after it has been deleted
+	invalidateCoverage("")
+	response.NoContent(c)
+}
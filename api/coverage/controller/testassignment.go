@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// AssignTests godoc
+// @Summary      Assign tests to an area and feature
+// @Description  Assigns all tests of a component, suite and file without area and feature to the specified area and feature. Future uploads of these tests which cannot be mapped are assigned as well.
+// @Tags         test-assignment
+// @Produce      json
+// @Param        id          path     int                   true  "Product ID"
+// @Param        assignment  body     model.TestAssignment  true  "Test assignment JSON"
+// @Success      200  {object}  model.TestAssignment
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/tests/assign [PUT]
+func AssignTests(c *gin.Context) {
+	var ta model.TestAssignment
+	if err := c.BindJSON(&ta); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding test assignment JSON", err))
+		return
+	}
+	pid, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid product ID", err))
+		return
+	}
+	ta.ProductId = pid
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if err := checkAreaAndFeature(repo, c.Param("id"), ta.AreaId, ta.FeatureId); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid area or feature", err))
+		return
+	}
+
+	assignments := []model.TestAssignment{ta}
+	err = repo.WithTx(func(tx *repository.CoverageStore) error {
+		return assignTests(tx, assignments)
+	})
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	invalidateCoverage(c.Param("id"))
+	response.ResponseWithDataAndMessage(c, http.StatusOK, assignments[0], "Tests assigned successfully")
+}
+
+// AssignTestsBulk godoc
+// @Summary      Assign all tests matching a filter to an area and feature
+// @Description  Assigns all tests without area and feature matching the filter to the specified area and feature. An assignment is stored for every component, suite and file, so future uploads are assigned as well.
+// @Tags         test-assignment
+// @Produce      json
+// @Param        id      path     int                         true  "Product ID"
+// @Param        filter  body     model.TestAssignmentFilter  true  "Filter with area and feature ID"
+// @Success      200  {array}  model.TestAssignment
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/tests/assign-bulk [PUT]
+func AssignTestsBulk(c *gin.Context) {
+	var filter model.TestAssignmentFilter
+	if err := c.BindJSON(&filter); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error binding filter JSON", err))
+		return
+	}
+	if filter.Component == "" && filter.Suite == "" && filter.File == "" {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid filter", fmt.Errorf("at least one of component, suite or file is required")))
+		return
+	}
+	pid, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid product ID", err))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if err := checkAreaAndFeature(repo, c.Param("id"), filter.AreaId, filter.FeatureId); err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid area or feature", err))
+		return
+	}
+
+	// Either all tests are assigned or none
+	var assignments []model.TestAssignment
+	err = repo.WithTx(func(tx *repository.CoverageStore) error {
+		var err error
+		if assignments, err = tx.GetUnassignedTests(c.Param("id"), filter); err != nil {
+			return err
+		}
+		for i := range assignments {
+			assignments[i].ProductId = pid
+			assignments[i].AreaId = filter.AreaId
+			assignments[i].FeatureId = filter.FeatureId
+		}
+		return assignTests(tx, assignments)
+	})
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	invalidateCoverage(c.Param("id"))
+	response.ResponseWithDataAndMessage(c, http.StatusOK, assignments, fmt.Sprintf("%d test(s) assigned successfully", len(assignments)))
+}
+
+// Stores the assignments and assigns their tests, the IDs of the assignments are set. It is called in a transaction, so
+// either all tests are assigned or none.
+func assignTests(tx *repository.CoverageStore, assignments []model.TestAssignment) error {
+	for i := range assignments {
+		ta := &assignments[i]
+		var err error
+		if ta.Id, err = tx.AssignTests(*ta); err != nil {
+			return fmt.Errorf("failed to assign tests: %w", err)
+		}
+	}
+	// All assignments have the same area and feature
+	if len(assignments) > 0 {
+		return tx.UpdateFirstUploads(assignments[0].AreaId, assignments[0].FeatureId)
+	}
+	return nil
+}
+
+// GetProductTestAssignments godoc
+// @Summary      Get all test assignments of a product
+// @Description  Get all test assignments of the specified product
+// @Tags         test-assignment
+// @Produce      json
+// @Param        id    path    int     true  "Product ID"
+// @Success      200  {array}  model.TestAssignment
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/test-assignments [GET]
+func GetProductTestAssignments(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	assignments, err := repo.GetTestAssignments(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, assignments)
+}
+
+// DeleteTestAssignment godoc
+// @Summary      Delete a test assignment
+// @Description  Delete a test assignment. Tests already assigned keep their area and feature.
+// @Tags         test-assignment
+// @Produce      json
+// @Param        id    path      int     true  "Test assignment ID"
+// @Success      204  {string}  SuccessResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/test-assignments/{id} [DELETE]
+func DeleteTestAssignment(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	affected, err := repo.DeleteTestAssignment(c.Param("id"))
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	if affected == 0 {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Test assignment with ID %s", c.Param("id"))))
+		return
+	}
+	response.NoContent(c)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTestHistory godoc
+// @Summary      Get the run history of a test
+// @Description  Get all results of a test in the coverage window, the oldest first, together with its current streak,
+// @Description  last failure, last pass, failure rate and mean time between failures.
+// @Tags         test
+// @Produce      json
+// @Param        id           path   int     true   "Product ID"
+// @Param        component    query  string  true   "Component name"
+// @Param        suite        query  string  true   "Suite name"
+// @Param        file-name    query  string  true   "File name"
+// @Param        branch       query  string  false  "Branch, default is the default branch of the product. Empty for all branches."
+// @Param        environment  query  string  false  "Environment"
+// @Param        days         query  int     false  "Number of days, default is the coverage window of the product"
+// @Param        from         query  string  false  "Start of the window (date or RFC 3339)"
+// @Param        to           query  string  false  "End of the window (date or RFC 3339)"
+// @Success      200  {object}  model.TestHistory
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/coverage/products/{id}/tests/history [GET]
+func GetTestHistory(c *gin.Context) {
+	component := c.Query("component")
+	suite := c.Query("suite")
+	file := strings.Replace(c.Query("file-name"), "\\\\", "\\", -1)
+	if suite == "" || file == "" {
+		errors.HandleError(c, errors.NewBadRequestError("Invalid test", fmt.Errorf("suite and file-name are required")))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	filter, ok := coverageFilter(c, c.Param("id"), repo.ProductCoverageFilter)
+	if !ok {
+		return
+	}
+	runs, err := repo.GetTestHistory(c.Param("id"), component, suite, file, filter)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+
+	history := testHistoryStats(runs)
+	history.Component, history.Suite, history.FileName = component, suite, file
+	response.OK(c, history)
+}
+
+// Computes the stats of the test results, which must be ordered by their test run, the oldest first
+func testHistoryStats(runs []model.TestHistoryEntry) model.TestHistory {
+	h := model.TestHistory{Runs: runs}
+	if len(runs) == 0 {
+		return h
+	}
+
+	var failed int64
+	var onsets []model.TestHistoryEntry
+	for i, r := range runs {
+		switch r.Status {
+		case model.TestFailed:
+			failed++
+			h.LastFailure = &runs[i].TestRun
+			if i == 0 || runs[i-1].Status != model.TestFailed {
+				onsets = append(onsets, r)
+			}
+		case model.TestPassed, model.TestPassedAfterRetry:
+			h.LastPass = &runs[i].TestRun
+		}
+	}
+	h.FailureRate = float64(failed) / float64(len(runs))
+	if len(onsets) > 1 {
+		h.MeanHoursBetweenFailures = onsets[len(onsets)-1].TestRun.Sub(onsets[0].TestRun).Hours() / float64(len(onsets)-1)
+	}
+
+	h.StreakStatus = runs[len(runs)-1].Status
+	for i := len(runs) - 1; i >= 0 && runs[i].Status == h.StreakStatus; i-- {
+		h.CurrentStreak++
+	}
+	return h
+}
@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// GetProductUnmappedTests godoc
+// @Summary      Get the unmapped tests of a product
+// @Description  Get the unknown area and feature names uploaded in strict mapping mode. By default only the pending ones are returned.
+// @Tags         unmapped-test
+// @Produce      json
+// @Param        id      path   int     true   "Product ID"
+// @Param        status  query  string  false  "pending, accepted, rejected or all"
+// @Success      200  {array}  model.UnmappedTest
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/products/{id}/unmapped-tests [GET]
+func GetProductUnmappedTests(c *gin.Context) {
+	status := c.DefaultQuery("status", model.UnmappedPending)
+	switch status {
+	case "all":
+		status = ""
+	case model.UnmappedPending, model.UnmappedAccepted, model.UnmappedRejected:
+	default:
+		errors.HandleError(c, errors.NewBadRequestError("Invalid status",
+			fmt.Errorf("status must be one of %s, %s, %s or all", model.UnmappedPending, model.UnmappedAccepted, model.UnmappedRejected)))
+		return
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	tests, err := repo.GetUnmappedTests(c.Param("id"), status)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	response.OK(c, tests)
+}
+
+// AcceptUnmappedTest godoc
+// @Summary      Accept an unmapped test
+// @Description  Maps the unmapped test to the area and feature in the body. Without body, area and feature are created using the uploaded names. All stored tests are linked to the area and feature.
+// @Tags         unmapped-test
+// @Produce      json
+// @Param        id       path  int                        true   "Unmapped test ID"
+// @Param        mapping  body  model.UnmappedTestMapping  false  "Area and feature ID"
+// @Success      200  {object}  model.UnmappedTest
+// @Failure      400  {object}  errors.ErrorResponse
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/unmapped-tests/{id}/accept [POST]
+func AcceptUnmappedTest(c *gin.Context) {
+	var m model.UnmappedTestMapping
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&m); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Error binding JSON", err))
+			return
+		}
+	}
+
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	u, ok := getUnmappedTest(c, repo)
+	if !ok {
+		return
+	}
+	pid := strconv.FormatInt(u.ProductId, 10)
+
+	create := m.AreaId == 0 && m.FeatureId == 0
+	if !create {
+		if err := checkAreaAndFeature(repo, pid, m.AreaId, m.FeatureId); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid area or feature", err))
+			return
+		}
+	}
+
+	// Created area and feature are only kept if the unmapped test is accepted and all stored tests are linked
+	err = repo.WithTx(func(tx *repository.CoverageStore) error {
+		if create {
+			var err error
+			if m.AreaId, m.FeatureId, _, err = findOrCreateAreaAndFeature(tx, pid, u.Area, u.Feature); err != nil {
+				return err
+			}
+		}
+		if err := tx.AcceptUnmappedTest(u.Id, m.AreaId, m.FeatureId); err != nil {
+			return fmt.Errorf("failed to accept unmapped test %d: %w", u.Id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+
+	invalidateCoverage(pid)
+	u.Status = model.UnmappedAccepted
+	u.AreaId = m.AreaId
+	u.FeatureId = m.FeatureId
+	response.ResponseWithDataAndMessage(c, http.StatusOK, u, "Unmapped test accepted successfully")
+}
+
+// RejectUnmappedTest godoc
+// @Summary      Reject an unmapped test
+// @Description  Rejects the unmapped test. Tests with these area and feature names are stored without area and feature.
+// @Tags         unmapped-test
+// @Produce      json
+// @Param        id    path  int  true  "Unmapped test ID"
+// @Success      200  {object}  model.UnmappedTest
+// @Failure      404  {object}  errors.ErrorResponse
+// @Failure      500  {object}  errors.ErrorResponse
+// @Router       /api/v1/unmapped-tests/{id}/reject [POST]
+func RejectUnmappedTest(c *gin.Context) {
+	repo, err := getRepository()
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+	u, ok := getUnmappedTest(c, repo)
+	if !ok {
+		return
+	}
+	if _, err := repo.RejectUnmappedTest(u.Id); err != nil {
+		errors.HandleError(c, errors.NewInternalError(fmt.Errorf("failed to reject unmapped test %d: %w", u.Id, err)))
+		return
+	}
+
+	u.Status = model.UnmappedRejected
+	response.ResponseWithDataAndMessage(c, http.StatusOK, u, "Unmapped test rejected successfully")
+}
+
+// Returns the unmapped test of the path, only pending ones can be accepted or rejected
+func getUnmappedTest(c *gin.Context, repo *repository.CoverageStore) (model.UnmappedTest, bool) {
+	u, err := repo.GetUnmappedTest(c.Param("id"))
+	if err == sql.ErrNoRows {
+		errors.HandleError(c, errors.NewNotFoundError(fmt.Sprintf("Unmapped test with ID %s", c.Param("id"))))
+		return u, false
+	}
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return u, false
+	}
+	if u.Status != model.UnmappedPending {
+		errors.HandleError(c, errors.NewBadRequestError("Unmapped test is not pending", fmt.Errorf("unmapped test %d is already %s", u.Id, u.Status)))
+		return u, false
+	}
+	return u, true
+}
+
+// Checks that the feature belongs to the area and the area to the product
+func checkAreaAndFeature(repo *repository.CoverageStore, pid string, areaId int64, featureId int64) error {
+	areas, err := repo.GetAllProductAreas(pid)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(areas, func(a model.Area) bool { return a.Id == areaId }) {
+		return fmt.Errorf("area %d does not belong to product %s", areaId, pid)
+	}
+	features, err := repo.GetAllAreaFeatures(strconv.FormatInt(areaId, 10))
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(features, func(f model.Feature) bool { return f.Id == featureId }) {
+		return fmt.Errorf("feature %d does not belong to area %d", featureId, areaId)
+	}
+	return nil
+}
+
+// Handles an unknown area and feature in strict mapping mode. The names are added to the triage queue, unless they
+// were already accepted or rejected. Returns the area and feature ID of an accepted mapping, otherwise the ID of
+// the unmapped test the stored test is linked to.
+func triageUnmappedTest(repo *repository.CoverageStore, pid string, tr reporter.TestResult) (int64, int64, int64, error) {
+	u, err := repo.GetUnmappedTestByName(pid, tr.Area, tr.Feature)
+	if err == sql.ErrNoRows {
+		id, err := repo.InsertUnmappedTest(pid, tr.Area, tr.Feature, tr.TestRun)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("error adding unmapped test: %w", err)
+		}
+		return 0, 0, id, nil
+	}
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("error getting unmapped test: %w", err)
+	}
+
+	switch u.Status {
+	case model.UnmappedAccepted:
+		return u.AreaId, u.FeatureId, 0, nil
+	case model.UnmappedRejected:
+		return 0, 0, 0, nil
+	}
+	if _, err := repo.CountUnmappedTest(u.Id, tr.TestRun); err != nil {
+		return 0, 0, 0, fmt.Errorf("error updating unmapped test: %w", err)
+	}
+	return 0, 0, u.Id, nil
+}
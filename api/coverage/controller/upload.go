@@ -0,0 +1,582 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controller
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+	"github.com/TestAndWin/e2e-coverage/coverage/repository"
+	"github.com/TestAndWin/e2e-coverage/errors"
+	"github.com/TestAndWin/e2e-coverage/logger"
+	"github.com/TestAndWin/e2e-coverage/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Envelope to send the CI metadata of the run together with the report. A JUnit XML report is sent as JSON string.
+type runEnvelope struct {
+	Run    model.Run       `json:"run"`
+	Report json.RawMessage `json:"report"`
+}
+
+// UploadReport godoc
+// @Summary      Add test results of a report of any supported format
+// @Description  Add test results of a report. The format is taken from the format header or detected from the report. The CI metadata of the run can be sent as headers or together with the report in a JSON envelope {"run": {...}, "report": ...}.
+// @Description  Either all test results are stored or none. In partial mode, the test results which could be stored are kept. The status of every test result is returned.
+// @Tags         upload
+// @Produce      json
+// @Param        id            path      int     true   "Product ID"
+// @Param        apiKey        header    string  true   "Api Key"
+// @Param        format        header    string  false  "Report format: mocha, junit, playwright or cucumber"
+// @Param        testReportUrl header    string  false  "Url of the detail test report"
+// @Param        component     header    string  false  "Component name"
+// @Param        runId         header    int     false  "ID of an existing run the results are added to"
+// @Param        commitSha     header    string  false  "Commit SHA of the run"
+// @Param        branch        header    string  false  "Branch of the run"
+// @Param        buildNumber   header    string  false  "Build number of the run"
+// @Param        jobUrl        header    string  false  "Url of the CI job"
+// @Param        environment   header    string  false  "Environment the tests were executed against"
+// @Param        triggeredBy   header    string  false  "User or event which triggered the run"
+// @Param        startedAt     header    string  false  "Start of the run (RFC 3339)"
+// @Param        endedAt       header    string  false  "End of the run (RFC 3339)"
+// @Param        partial       header    bool    false  "Keep the stored test results if other test results fail"
+// @Param        test          body      string  true   "Test report"
+// @Success      201  {object} model.UploadResponse
+// @Success      207  {object} model.UploadResponse
+// @Failure      400  {string}  ErrorResponse
+// @Failure      500  {object} model.UploadResponse
+// @Router       /api/v1/coverage/{id}/upload [POST]
+func UploadReport(c *gin.Context) {
+	body, run, err := readUpload(c)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error reading report", err))
+		return
+	}
+
+	// The format header wins over the detection, e.g. if a report matches more than one format
+	format := strings.ToLower(c.GetHeader("format"))
+	var r reporter.Reporter
+	var ok bool
+	if format != "" {
+		r, ok = reporter.Get(format)
+	} else {
+		r, ok = reporter.Detect(body)
+	}
+	if !ok {
+		errors.HandleError(c, errors.NewAppError(
+			fmt.Errorf("supported formats are %s", strings.Join(reporter.Names(), ", ")),
+			"Unknown report format",
+			"UNKNOWN_REPORT_FORMAT",
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	testResults, err := r.Parse(body)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError(fmt.Sprintf("Error reading %s result", r.Name()), err))
+		return
+	}
+	uploadTestResults(c, run, testResults)
+}
+
+// Reads the report with the specified format and stores its test results
+func uploadReport(c *gin.Context, format string) {
+	body, run, err := readUpload(c)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError("Error reading report", err))
+		return
+	}
+	r, _ := reporter.Get(format)
+	testResults, err := r.Parse(body)
+	if err != nil {
+		errors.HandleError(c, errors.NewBadRequestError(fmt.Sprintf("Error reading %s result", format), err))
+		return
+	}
+	uploadTestResults(c, run, testResults)
+}
+
+// Reads the report and the CI metadata of the run from the request. Values of the envelope win over the headers.
+func readUpload(c *gin.Context) ([]byte, model.Run, error) {
+	run := model.Run{
+		CommitSha:   c.GetHeader("commitSha"),
+		Branch:      c.GetHeader("branch"),
+		BuildNumber: c.GetHeader("buildNumber"),
+		JobUrl:      c.GetHeader("jobUrl"),
+		Environment: c.GetHeader("environment"),
+		TriggeredBy: c.GetHeader("triggeredBy"),
+	}
+	var err error
+	if h := c.GetHeader("runId"); h != "" {
+		if run.Id, err = strconv.ParseInt(h, 10, 64); err != nil {
+			return nil, run, fmt.Errorf("invalid run ID: %w", err)
+		}
+	}
+	if run.StartedAt, err = parseRunTime(c.GetHeader("startedAt")); err != nil {
+		return nil, run, err
+	}
+	if run.EndedAt, err = parseRunTime(c.GetHeader("endedAt")); err != nil {
+		return nil, run, err
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, run, err
+	}
+
+	keys := reporter.JSONObjectKeys(body)
+	_, hasRun := keys["run"]
+	_, hasReport := keys["report"]
+	if !hasRun || !hasReport {
+		return body, run, nil
+	}
+	var envelope runEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, run, fmt.Errorf("error parsing run envelope: %w", err)
+	}
+	mergeRun(&run, envelope.Run)
+
+	// A report which is not JSON, e.g. JUnit XML, is embedded as string
+	var report string
+	if err := json.Unmarshal(envelope.Report, &report); err == nil {
+		return []byte(report), run, nil
+	}
+	return envelope.Report, run, nil
+}
+
+func parseRunTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid run time %s: %w", value, err)
+	}
+	return t, nil
+}
+
+// Sets all values of the run which are set in the other run
+func mergeRun(run *model.Run, other model.Run) {
+	for _, v := range []struct {
+		value *string
+		other string
+	}{
+		{&run.CommitSha, other.CommitSha},
+		{&run.Branch, other.Branch},
+		{&run.BuildNumber, other.BuildNumber},
+		{&run.JobUrl, other.JobUrl},
+		{&run.Environment, other.Environment},
+		{&run.TriggeredBy, other.TriggeredBy},
+	} {
+		if v.other != "" {
+			*v.value = v.other
+		}
+	}
+	if other.Id != 0 {
+		run.Id = other.Id
+	}
+	if !other.StartedAt.IsZero() {
+		run.StartedAt = other.StartedAt
+	}
+	if !other.EndedAt.IsZero() {
+		run.EndedAt = other.EndedAt
+	}
+}
+
+// Stores the test results read from a report for the product given in the path
+func uploadTestResults(c *gin.Context, run model.Run, testResults []reporter.TestResult) {
+	pid := c.Param("id")
+	testReportUrl := c.GetHeader("testReportUrl")
+	component := c.GetHeader("component")
+	partial := false
+	if h := c.GetHeader("partial"); h != "" {
+		var err error
+		if partial, err = strconv.ParseBool(h); err != nil {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid partial header", err))
+			return
+		}
+	}
+
+	// Results of an existing run can only be added to the run of the same product
+	if run.Id != 0 {
+		repo, err := getRepository()
+		if err != nil {
+			errors.HandleError(c, errors.NewInternalError(err))
+			return
+		}
+		existing, err := repo.GetRun(strconv.FormatInt(run.Id, 10))
+		if err != nil && err != sql.ErrNoRows {
+			errors.HandleError(c, errors.NewInternalError(err))
+			return
+		}
+		if err == sql.ErrNoRows || strconv.FormatInt(existing.ProductId, 10) != pid {
+			errors.HandleError(c, errors.NewBadRequestError("Invalid run ID", fmt.Errorf("run %d does not belong to product %s", run.Id, pid)))
+			return
+		}
+		// The test results belong to the branch and environment of the run, only its time is extended
+		existing.StartedAt, existing.EndedAt = run.StartedAt, run.EndedAt
+		run = existing
+	}
+
+	result, err := processTestResults(testResults, pid, testReportUrl, component, run, partial)
+	// Also after an error, as in partial mode some of the test results might have been stored
+	invalidateCoverage(pid)
+	if err != nil {
+		errors.HandleError(c, errors.NewInternalError(err))
+		return
+	}
+
+	switch {
+	case result.Failed == 0:
+		response.Created(c, result)
+	case partial:
+		response.ResponseWithFailure(c, http.StatusMultiStatus, result,
+			fmt.Sprintf("%d of %d test results could not be stored", result.Failed, len(result.Results)))
+	default:
+		response.ResponseWithFailure(c, http.StatusInternalServerError, result, "Upload rolled back, no test result was stored")
+	}
+}
+
+// Product data which is needed for all test results of an upload
+type upload struct {
+	productId     string
+	testReportUrl string
+	component     string
+	settings      model.ProductSettings
+	rules         []compiledMappingRule
+	// The run is stored with the first test result, so uploading a report again does not create an empty run
+	run       model.Run
+	runStored bool
+}
+
+// Stores the test results, either all of them in one transaction or, in partial mode, every test result in its own
+// transaction. An error is only returned if the upload could not be processed at all.
+func processTestResults(testResults []reporter.TestResult, pid, testReportUrl, component string, run model.Run, partial bool) (model.UploadResponse, error) {
+	resp := model.UploadResponse{Partial: partial, Results: []model.UploadResult{}}
+	repo, err := getRepository()
+	if err != nil {
+		return resp, err
+	}
+	settings, err := repo.GetProductSettings(pid)
+	if err != nil {
+		return resp, fmt.Errorf("error getting product settings: %w", err)
+	}
+	rules, err := loadMappingRules(pid)
+	if err != nil {
+		return resp, err
+	}
+	u := &upload{productId: pid, testReportUrl: testReportUrl, component: component, settings: settings, rules: rules,
+		run: run}
+	u.setRunTimes(testResults)
+
+	if partial {
+		for _, tr := range testResults {
+			resp.Results = append(resp.Results, u.processInTx(repo, tr))
+		}
+	} else {
+		err = repo.WithTx(func(tx *repository.CoverageStore) error {
+			for i, tr := range testResults {
+				res, err := u.process(tx, tr)
+				resp.Results = append(resp.Results, res)
+				if err != nil {
+					for _, tr := range testResults[i+1:] {
+						resp.Results = append(resp.Results, newUploadResult(u, tr, model.UploadNotProcessed))
+					}
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// The run is rolled back as well
+			u.runStored = false
+			failed := false
+			for i := range resp.Results {
+				switch resp.Results[i].Status {
+				case model.UploadFailed:
+					failed = true
+					clearUploadResult(&resp.Results[i], model.UploadFailed)
+				case model.UploadCreated:
+					clearUploadResult(&resp.Results[i], model.UploadRolledBack)
+				}
+			}
+			// Committing failed, no test result is to blame
+			if !failed {
+				return resp, err
+			}
+		}
+	}
+
+	if u.runStored {
+		resp.RunId = u.run.Id
+	}
+	for _, res := range resp.Results {
+		switch res.Status {
+		case model.UploadCreated:
+			resp.Created++
+		case model.UploadDuplicate:
+			resp.Duplicates++
+		case model.UploadFailed:
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// Stores the test result in its own transaction, the transaction is rolled back if the test result fails
+func (u *upload) processInTx(repo *repository.CoverageStore, tr reporter.TestResult) model.UploadResult {
+	// A run stored with the test result is rolled back as well, so it is stored again with the next one
+	run, runStored := u.run, u.runStored
+	var res model.UploadResult
+	err := repo.WithTx(func(tx *repository.CoverageStore) error {
+		var err error
+		res, err = u.process(tx, tr)
+		return err
+	})
+	if err != nil {
+		u.run, u.runStored = run, runStored
+		if res.Status != model.UploadFailed {
+			// Committing failed
+			failUploadResult(&res, model.UploadErrorInternal, err)
+		}
+		clearUploadResult(&res, model.UploadFailed)
+	}
+	return res
+}
+
+// Applies the mapping rules to the test result and stores it
+func (u *upload) process(repo *repository.CoverageStore, tr reporter.TestResult) (model.UploadResult, error) {
+	tr = applyMappingRules(tr, u.rules)
+	res := newUploadResult(u, tr, model.UploadCreated)
+	if err := processTestResult(repo, u, tr, &res); err != nil {
+		logger.Errorf("Error processing test result %s: %v", tr.Uuid, err)
+		return res, err
+	}
+	return res, nil
+}
+
+func newUploadResult(u *upload, tr reporter.TestResult, status string) model.UploadResult {
+	return model.UploadResult{Uuid: tr.Uuid, Component: resultComponent(u.component, tr), Suite: tr.Suite, FileName: tr.File,
+		Status: status, CreatedEntities: []model.UploadEntity{}}
+}
+
+func failUploadResult(res *model.UploadResult, code string, err error) error {
+	res.Status = model.UploadFailed
+	res.ErrorCode = code
+	res.Error = err.Error()
+	return err
+}
+
+// Nothing of a test result which is not stored is returned
+func clearUploadResult(res *model.UploadResult, status string) {
+	res.Status = status
+	res.TestId, res.AreaId, res.FeatureId, res.UnmappedId = 0, 0, 0, 0
+	res.CreatedEntities = []model.UploadEntity{}
+}
+
+// Returns the component of the test result. Some reporters provide a component per result, e.g. the
+// Playwright project. It is appended to the component sent in the header.
+func resultComponent(component string, tr reporter.TestResult) string {
+	switch {
+	case tr.Component == "":
+		return component
+	case component == "":
+		return tr.Component
+	default:
+		return component + "/" + tr.Component
+	}
+}
+
+// Stores the test result and sets its status. If it fails, the error code of the status is set as well.
+func processTestResult(repo *repository.CoverageStore, u *upload, tr reporter.TestResult, res *model.UploadResult) error {
+	pid := u.productId
+	component := res.Component
+
+	uploaded, err := repo.HasTestBeenUploaded(tr.Uuid)
+	if err != nil {
+		return failUploadResult(res, model.UploadErrorDuplicateCheck, fmt.Errorf("error checking if test was uploaded: %w", err))
+	}
+	if uploaded {
+		res.Status = model.UploadDuplicate
+		return nil
+	}
+
+	aid, fid, err := repo.GetAreaAndFeatureId(tr.Area, tr.Feature, pid)
+	if err == sql.ErrNoRows && tr.Area == "" && tr.Feature != "" {
+		// Without area, the feature is mapped if its name is unique in the product
+		aid, fid, err = repo.GetAreaAndFeatureIdByFeatureName(tr.Feature, pid)
+	}
+	if err != nil && err != sql.ErrNoRows {
+		return failUploadResult(res, model.UploadErrorMapping, fmt.Errorf("error getting area and feature ID: %w", err))
+	}
+
+	// Unknown area and feature (when both are specified) are either created automatically or,
+	// in strict mapping mode, added to the triage queue
+	var unmappedId int64
+	if err == sql.ErrNoRows && tr.Area != "" && tr.Feature != "" {
+		if u.settings.StrictMapping {
+			aid, fid, unmappedId, err = triageUnmappedTest(repo, pid, tr)
+		} else {
+			var created []model.UploadEntity
+			aid, fid, created, err = findOrCreateAreaAndFeature(repo, pid, tr.Area, tr.Feature)
+			res.CreatedEntities = append(res.CreatedEntities, created...)
+		}
+		if err != nil {
+			return failUploadResult(res, model.UploadErrorMapping, err)
+		}
+	}
+
+	// Tests which cannot be mapped may have been assigned by a maintainer
+	if aid == 0 || fid == 0 {
+		aid, fid, err = repo.GetTestAssignment(pid, component, tr.Suite, tr.File)
+		if err != nil {
+			return failUploadResult(res, model.UploadErrorMapping, fmt.Errorf("error getting test assignment: %w", err))
+		}
+		if aid != 0 && fid != 0 {
+			unmappedId = 0
+		}
+	}
+
+	isFirst, err := repo.IsThisTheFirstUpload(pid, aid, fid, tr.Suite, tr.File, component)
+	if err != nil {
+		return failUploadResult(res, model.UploadErrorInsert, fmt.Errorf("error checking if this is the first upload: %w", err))
+	}
+
+	newRun := !u.runStored && u.run.Id == 0
+	if err := u.storeRun(repo); err != nil {
+		return failUploadResult(res, model.UploadErrorRun, err)
+	}
+	if newRun {
+		res.CreatedEntities = append(res.CreatedEntities, model.UploadEntity{Type: model.UploadEntityRun, Id: u.run.Id})
+	}
+
+	var id int64
+	if aid != 0 && fid != 0 {
+		id, err = repo.InsertTestResult(pid, aid, fid, component, u.testReportUrl, isFirst, u.run, tr)
+	} else {
+		id, err = repo.InsertTestResultWithoutAreaFeature(pid, component, u.testReportUrl, isFirst, unmappedId, u.run, tr)
+	}
+	if err != nil {
+		return failUploadResult(res, model.UploadErrorInsert, fmt.Errorf("error inserting test result: %w", err))
+	}
+	if err := repo.InsertTestCases(id, tr.Cases); err != nil {
+		return failUploadResult(res, model.UploadErrorInsert, err)
+	}
+	// The test result is stored, a failure of the flakiness update must not fail the upload
+	if err := repo.UpdateTestFlakiness(pid, component, tr.Suite, tr.File, u.settings); err != nil {
+		logger.Errorf("Error updating flakiness of test %s %s: %v", tr.Suite, tr.File, err)
+	}
+
+	res.TestId, res.AreaId, res.FeatureId, res.UnmappedId = id, aid, fid, unmappedId
+	return nil
+}
+
+// Without start and end time, the run starts with the first and ends with the last test result of the report
+func (u *upload) setRunTimes(testResults []reporter.TestResult) {
+	for _, tr := range testResults {
+		if u.run.StartedAt.IsZero() || tr.TestRun.Before(u.run.StartedAt) {
+			u.run.StartedAt = tr.TestRun
+		}
+		if u.run.EndedAt.IsZero() || tr.TestRun.After(u.run.EndedAt) {
+			u.run.EndedAt = tr.TestRun
+		}
+	}
+}
+
+// Stores a new run or extends the time of an existing one
+func (u *upload) storeRun(repo *repository.CoverageStore) error {
+	if u.runStored {
+		return nil
+	}
+	var err error
+	if u.run.Id != 0 {
+		_, err = repo.ExtendRun(u.run.Id, u.run.StartedAt, u.run.EndedAt)
+	} else {
+		u.run.ProductId, _ = strconv.ParseInt(u.productId, 10, 64)
+		u.run.Id, err = repo.InsertRun(u.run)
+	}
+	if err != nil {
+		return fmt.Errorf("error storing run: %w", err)
+	}
+	u.runStored = true
+	return nil
+}
+
+// Returns the IDs of the area and feature with the specified names. Area and feature are created if they don't exist,
+// the created ones are returned as well.
+func findOrCreateAreaAndFeature(repo *repository.CoverageStore, pid string, areaName string, featureName string) (int64, int64, []model.UploadEntity, error) {
+	logger.Debugf("Area '%s' and Feature '%s' not found together, checking if they exist separately", areaName, featureName)
+
+	// Convert product ID from string to int64
+	productID, err := strconv.ParseInt(pid, 10, 64)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	var created []model.UploadEntity
+	// First check if area exists by name and product ID
+	areaId, err := repo.GetAreaIdByNameAndProductId(areaName, pid)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, 0, nil, fmt.Errorf("error checking if area exists: %w", err)
+	}
+
+	// If area doesn't exist, create it
+	if err == sql.ErrNoRows {
+		logger.Debugf("Area '%s' not found, creating it", areaName)
+		area := model.Area{
+			ProductId: productID,
+			Name:      areaName,
+		}
+		areaId, err = repo.InsertArea(area)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("error creating area: %w", err)
+		}
+		logger.Debugf("Successfully created area '%s' with ID %d", areaName, areaId)
+		created = append(created, model.UploadEntity{Type: model.UploadEntityArea, Id: areaId, Name: areaName})
+	} else {
+		logger.Debugf("Found existing area '%s' with ID %d", areaName, areaId)
+	}
+
+	// Now that we have areaId (either existing or newly created),
+	// check if feature exists in this area
+	featureId, err := repo.GetFeatureIdByNameAndAreaId(featureName, areaId)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, 0, nil, fmt.Errorf("error checking if feature exists: %w", err)
+	}
+
+	// If feature doesn't exist in this area, create it
+	if err == sql.ErrNoRows {
+		logger.Debugf("Feature '%s' not found in area %d, creating it", featureName, areaId)
+		feature := model.Feature{
+			AreaId:        areaId,
+			Name:          featureName,
+			Documentation: "",       // Default empty documentation
+			Url:           "",       // Default empty URL
+			BusinessValue: "medium", // Default medium business value
+		}
+		featureId, err = repo.InsertFeature(feature)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("error creating feature: %w", err)
+		}
+		logger.Debugf("Successfully created feature '%s' with ID %d", featureName, featureId)
+		created = append(created, model.UploadEntity{Type: model.UploadEntityFeature, Id: featureId, Name: featureName})
+	} else {
+		logger.Debugf("Found existing feature '%s' with ID %d", featureName, featureId)
+	}
+
+	return areaId, featureId, created, nil
+}
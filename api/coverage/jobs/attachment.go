@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/dependency"
+)
+
+const attachmentInterval = time.Hour
+
+// ExpiredAttachments returns the job deleting the attachments older than the retention and the ones of deleted test results
+func ExpiredAttachments() Job {
+	return Job{Name: "expired attachments", Interval: attachmentInterval, Run: deleteExpiredAttachments}
+}
+
+func deleteExpiredAttachments() error {
+	container := dependency.GetContainer()
+	repo, err := container.GetCoverageStore()
+	if err != nil {
+		return err
+	}
+	store, err := container.GetBlobStore()
+	if err != nil {
+		return err
+	}
+	retention := container.GetConfig().AttachmentRetentionDays
+	attachments, err := repo.GetExpiredAttachments(time.Now().AddDate(0, 0, -retention))
+	if err != nil {
+		return fmt.Errorf("error getting expired attachments: %w", err)
+	}
+
+	// The content is deleted first, so an attachment is never listed without content
+	for _, a := range attachments {
+		if err := store.Delete(context.Background(), a.BlobKey); err != nil {
+			return fmt.Errorf("error deleting content of attachment %d: %w", a.Id, err)
+		}
+		if _, err := repo.DeleteAttachment(strconv.FormatInt(a.Id, 10)); err != nil {
+			return fmt.Errorf("error deleting attachment %d: %w", a.Id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/logger"
+)
+
+// Job is a task which is executed periodically in the background
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Start executes every job right away and then in its interval, each job in its own goroutine.
+// A failing run is logged, the job is executed again in the next interval.
+func Start(jobs ...Job) {
+	for _, j := range jobs {
+		go run(j)
+	}
+}
+
+func run(j Job) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+	for {
+		logger.Debugf("Running job %s", j.Name)
+		if err := j.Run(); err != nil {
+			logger.Errorf("Error running job %s: %v", j.Name, err)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/dependency"
+)
+
+// Quarantines end when they expire, the job only removes them and records it in the audit log
+const quarantineInterval = 5 * time.Minute
+
+// ExpiredQuarantines returns the job removing the expired test quarantines of all products
+func ExpiredQuarantines() Job {
+	return Job{Name: "expired quarantines", Interval: quarantineInterval, Run: removeExpiredQuarantines}
+}
+
+func removeExpiredQuarantines() error {
+	repo, err := dependency.GetContainer().GetCoverageStore()
+	if err != nil {
+		return err
+	}
+	quarantines, err := repo.GetExpiredTestQuarantines()
+	if err != nil {
+		return fmt.Errorf("error getting expired quarantines: %w", err)
+	}
+
+	for _, q := range quarantines {
+		if _, err := repo.DeleteTestQuarantine(strconv.FormatInt(q.Id, 10)); err != nil {
+			return fmt.Errorf("error deleting quarantine %d: %w", q.Id, err)
+		}
+		if _, err := repo.InsertAuditEntry(model.AuditEntry{ProductId: q.ProductId, Action: model.AuditQuarantineExpired,
+			Entity: model.AuditEntityQuarantine, EntityId: q.Id, Actor: model.AuditActorSystem,
+			Details: fmt.Sprintf("component %q, suite %q, file %q, expired at %s", q.Component, q.Suite, q.FileName,
+				q.ExpiresAt.Format(time.RFC3339))}); err != nil {
+			return fmt.Errorf("error adding expired quarantine %d to the audit log: %w", q.Id, err)
+		}
+		dependency.GetContainer().GetCoverageCache().Invalidate(strconv.FormatInt(q.ProductId, 10))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/dependency"
+	"github.com/TestAndWin/e2e-coverage/logger"
+)
+
+// The snapshot of the current day is updated every hour, so the last update of a day is at most one hour old
+const snapshotInterval = time.Hour
+
+// CoverageSnapshots returns the job storing the daily coverage snapshots of all products
+func CoverageSnapshots() Job {
+	return Job{Name: "coverage snapshots", Interval: snapshotInterval, Run: takeCoverageSnapshots}
+}
+
+// Takes the snapshots of today and yesterday. Yesterday is taken again, to include the test results which were
+// uploaded after the last run of the job on that day.
+func takeCoverageSnapshots() error {
+	repo, err := dependency.GetContainer().GetCoverageStore()
+	if err != nil {
+		return err
+	}
+	products, err := repo.GetAllProducts()
+	if err != nil {
+		return fmt.Errorf("error getting products: %w", err)
+	}
+
+	// A failing product does not stop the snapshots of the other products
+	now := time.Now().UTC()
+	failed := 0
+	for _, p := range products {
+		pid := strconv.FormatInt(p.Id, 10)
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			if err := repo.TakeCoverageSnapshot(pid, day); err != nil {
+				logger.Errorf("Error taking coverage snapshot of product %s: %v", pid, err)
+				failed++
+				break
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("snapshots of %d products failed", failed)
+	}
+	return nil
+}
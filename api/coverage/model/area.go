@@ -20,4 +20,8 @@ type Area struct {
 	Skipped    int64   `json:"skipped"`
 	ExplTests  int64   `json:"expl-tests"`
 	ExplRating float64 `json:"expl-rating"`
+	// Failures of quarantined tests, they are not part of the failures
+	Quarantined int64 `json:"quarantined"`
+	// Tests which passed after a retry, they are part of the passes as well
+	RetryPasses int64 `json:"retry-passes"`
 }
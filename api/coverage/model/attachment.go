@@ -0,0 +1,24 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// Attachment is a file of a test result, e.g. a screenshot or a video of a failed test. The file is in the blob store.
+type Attachment struct {
+	Id          int64     `db:"id"           json:"id"`
+	ProductId   int64     `db:"product_id"   json:"product-id"`
+	TestId      int64     `db:"test_id"      json:"test-id"`
+	Name        string    `db:"name"         json:"name"`
+	ContentType string    `db:"content_type" json:"content-type"`
+	Size        int64     `db:"size"         json:"size"`
+	BlobKey     string    `db:"blob_key"     json:"-"`
+	CreatedBy   string    `db:"created_by"   json:"created-by"`
+	CreatedAt   time.Time `db:"created_at"   json:"created-at"`
+}
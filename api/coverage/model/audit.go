@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// Actions of the audit log
+const (
+	AuditQuarantineAdded   = "quarantine-added"
+	AuditQuarantineRemoved = "quarantine-removed"
+	AuditQuarantineExpired = "quarantine-expired"
+)
+
+// Entities of the audit log
+const AuditEntityQuarantine = "test-quarantine"
+
+// Actor of the changes done by the application itself, e.g. expired quarantines
+const AuditActorSystem = "system"
+
+// AuditEntry records who changed what and when
+type AuditEntry struct {
+	Id        int64     `db:"id"         json:"id"`
+	ProductId int64     `db:"product_id" json:"product-id"`
+	Action    string    `db:"action"     json:"action"`
+	Entity    string    `db:"entity"     json:"entity"`
+	EntityId  int64     `db:"entity_id"  json:"entity-id"`
+	Actor     string    `db:"actor"      json:"actor"`
+	Details   string    `db:"details"    json:"details"`
+	CreatedAt time.Time `db:"created_at" json:"created-at"`
+}
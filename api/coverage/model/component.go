@@ -18,4 +18,6 @@ type Component struct {
 	Pending  int64     `json:"pending"`
 	Failures int64     `json:"failures"`
 	Skipped  int64     `json:"skipped"`
+	// Tests which passed after a retry, they are part of the passes as well
+	RetryPasses int64 `json:"retry-passes"`
 }
@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// DurationPoint is the duration of a test result or a test case in ms
+type DurationPoint struct {
+	TestId   int64     `json:"test-id"`
+	TestRun  time.Time `json:"test-run"`
+	Duration int64     `json:"duration"`
+	Status   string    `json:"status"`
+}
+
+// SuiteDuration is the duration of a suite in the time window, the durations are in ms
+type SuiteDuration struct {
+	Component string    `json:"component"`
+	Suite     string    `json:"suite"`
+	FileName  string    `json:"file-name"`
+	Runs      int64     `json:"runs"`
+	Median    int64     `json:"median"`
+	Mean      int64     `json:"mean"`
+	Max       int64     `json:"max"`
+	Latest    int64     `json:"latest"`
+	LastRun   time.Time `json:"last-run"`
+}
+
+// DurationRegression is a suite whose median duration grew compared to the baseline window, the durations are in ms
+type DurationRegression struct {
+	Component      string  `json:"component"`
+	Suite          string  `json:"suite"`
+	FileName       string  `json:"file-name"`
+	BaselineRuns   int64   `json:"baseline-runs"`
+	BaselineMedian int64   `json:"baseline-median"`
+	CurrentRuns    int64   `json:"current-runs"`
+	CurrentMedian  int64   `json:"current-median"`
+	IncreasePct    float64 `json:"increase-pct"`
+}
@@ -0,0 +1,37 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// FailureCluster groups the failed test cases with the same failure signature, they very likely have the same root cause
+type FailureCluster struct {
+	Signature string `json:"signature"`
+	// Message of the latest failure, and its message without numbers, ids and timestamps
+	Message           string       `json:"message"`
+	NormalizedMessage string       `json:"normalized-message"`
+	Stack             string       `json:"stack"`
+	Failures          int64        `json:"failures"`
+	Suites            int64        `json:"suites"`
+	FirstSeen         time.Time    `json:"first-seen"`
+	LastSeen          time.Time    `json:"last-seen"`
+	Tests             []FailedTest `json:"tests"`
+}
+
+// FailedTest is a failed test case of a failure cluster
+type FailedTest struct {
+	TestId     int64     `json:"test-id"`
+	TestCaseId int64     `json:"test-case-id"`
+	Component  string    `json:"component"`
+	Suite      string    `json:"suite"`
+	FileName   string    `json:"file-name"`
+	Title      string    `json:"title"`
+	TestRun    time.Time `json:"test-run"`
+	Url        string    `json:"url"`
+}
@@ -22,4 +22,8 @@ type Feature struct {
 	Failures      int64  `json:"failures"`
 	Skipped       int64  `json:"skipped"`
 	Tests         []Test `json:"tests"`
+	// Failures of quarantined tests, they are not part of the failures
+	Quarantined int64 `json:"quarantined"`
+	// Tests which passed after a retry, they are part of the passes as well
+	RetryPasses int64 `json:"retry-passes"`
 }
@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// TestFlakiness is computed from the latest results of a test, the number of results is set by the flaky window of the product.
+// A test is flaky, if its status flipped between two results of the same commit, if it flipped more than once, or if
+// tests passed only after a retry.
+type TestFlakiness struct {
+	Id        int64  `db:"id"         json:"id"`
+	ProductId int64  `db:"product_id" json:"product-id"`
+	Component string `db:"component"  json:"component"`
+	Suite     string `db:"suite"      json:"suite"`
+	FileName  string `db:"file"       json:"file-name"`
+	// Number of passed and failed results, skipped results are not considered
+	Runs            int64 `db:"runs"              json:"runs"`
+	Flips           int64 `db:"flips"             json:"flips"`
+	SameCommitFlips int64 `db:"same_commit_flips" json:"same-commit-flips"`
+	// Between 0 and 1, flips on the same commit count double, results which passed after a retry count as well
+	Score    float64    `db:"score"     json:"score"`
+	Flaky    bool       `db:"flaky"     json:"flaky"`
+	LastFlip *time.Time `db:"last_flip" json:"last-flip"`
+	History  []TestFlip `json:"history"`
+	// Number of results with tests which passed after a retry, they count as passed for the flips
+	RetriedRuns int64 `db:"retried_runs" json:"retried-runs"`
+}
+
+// TestFlip is a change of the status between two results of a test
+type TestFlip struct {
+	TestId     int64     `json:"test-id"`
+	TestRun    time.Time `json:"test-run"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	CommitSha  string    `json:"commit-sha"`
+	SameCommit bool      `json:"same-commit"`
+}
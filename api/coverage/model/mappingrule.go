@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+// Types of mapping rules
+const (
+	// Regular expression on the suite title
+	MappingRuleTitle = "title"
+	// Glob pattern on the spec file path, e.g. cypress/e2e/checkout/**
+	MappingRuleFile = "file"
+	// Regular expression on the tags of a test result
+	MappingRuleTag = "tag"
+)
+
+// MappingRule maps test results without an {area}|{feature}|{suite} title to an area and feature.
+// Area and feature can reference groups of the pattern, e.g. $1 or ${name}.
+type MappingRule struct {
+	Id        int64  `db:"id"          json:"id"`
+	ProductId int64  `db:"product_id"  json:"product-id"`
+	Priority  int64  `db:"priority"    json:"priority"`
+	Type      string `db:"rule_type"   json:"type"`
+	Pattern   string `db:"pattern"     json:"pattern"`
+	Area      string `db:"area"        json:"area"`
+	Feature   string `db:"feature"     json:"feature"`
+}
@@ -12,3 +12,18 @@ type Product struct {
 	Id   int64  `db:"id"   json:"id"`
 	Name string `db:"name" json:"name"`
 }
+
+// ProductSettings controls how test results of a product are mapped and evaluated
+type ProductSettings struct {
+	ProductId int64 `db:"id"             json:"product-id"`
+	// Unknown areas and features are not created automatically but added to the triage queue
+	StrictMapping bool `db:"strict_mapping" json:"strict-mapping"`
+	// Coverage is calculated for this branch, unless another branch is requested
+	DefaultBranch string `db:"default_branch" json:"default-branch"`
+	// Number of days of the coverage window
+	CoverageDays int64 `db:"coverage_days" json:"coverage-days"`
+	// Number of the latest results of a test used to detect flaky tests
+	FlakyWindow int64 `db:"flaky_window" json:"flaky-window"`
+	// Increase of the median duration of a suite in percent, which is reported as regression
+	DurationRegressionPct int64 `db:"duration_regression_pct" json:"duration-regression-pct"`
+}
@@ -0,0 +1,26 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// TestQuarantine excludes the failures of a known flaky test from the failure totals of its area and feature until it
+// expires. The failures are reported as quarantined instead.
+type TestQuarantine struct {
+	Id        int64     `db:"id"         json:"id"`
+	ProductId int64     `db:"product_id" json:"product-id"`
+	Component string    `db:"component"  json:"component"`
+	Suite     string    `db:"suite"      json:"suite"`
+	FileName  string    `db:"file"       json:"file-name"`
+	Reason    string    `db:"reason"     json:"reason"`
+	Owner     string    `db:"owner"      json:"owner"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires-at"`
+	CreatedBy string    `db:"created_by" json:"created-by"`
+	CreatedAt time.Time `db:"created_at" json:"created-at"`
+}
@@ -0,0 +1,34 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// Run is one execution of a CI/CD pipeline. All test results uploaded by the pipeline belong to the run.
+type Run struct {
+	Id          int64     `db:"id"           json:"id"`
+	ProductId   int64     `db:"product_id"   json:"product-id"`
+	CommitSha   string    `db:"commit_sha"   json:"commit-sha"`
+	Branch      string    `db:"branch"       json:"branch"`
+	BuildNumber string    `db:"build_number" json:"build-number"`
+	JobUrl      string    `db:"job_url"      json:"job-url"`
+	Environment string    `db:"environment"  json:"environment"`
+	TriggeredBy string    `db:"triggered_by" json:"triggered-by"`
+	StartedAt   time.Time `db:"started_at"   json:"started-at"`
+	EndedAt     time.Time `db:"ended_at"     json:"ended-at"`
+	// Sum of all test results of the run
+	Tests    int64 `json:"tests"`
+	Total    int64 `json:"total"`
+	Passes   int64 `json:"passes"`
+	Pending  int64 `json:"pending"`
+	Failures int64 `json:"failures"`
+	Skipped  int64 `json:"skipped"`
+	// Tests which passed after a retry, they are part of the passes as well
+	RetryPasses int64 `json:"retry-passes"`
+}
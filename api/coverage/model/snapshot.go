@@ -0,0 +1,27 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+// CoverageSnapshot is the coverage of a product, an area or a feature at the end of a day.
+// The area and feature ID are 0 for the rollup of the product, the feature ID is 0 for the rollup of an area.
+type CoverageSnapshot struct {
+	Id         int64  `db:"id"            json:"id"`
+	ProductId  int64  `db:"product_id"    json:"product-id"`
+	AreaId     int64  `db:"area_id"       json:"area-id"`
+	FeatureId  int64  `db:"feature_id"    json:"feature-id"`
+	Date       string `db:"snapshot_date" json:"date"`
+	Total      int64  `db:"total"         json:"total"`
+	FirstTotal int64  `db:"first_total"   json:"first-total"`
+	Passes     int64  `db:"passes"        json:"passes"`
+	Pending    int64  `db:"pending"       json:"pending"`
+	Failures   int64  `db:"failures"      json:"failures"`
+	Skipped    int64  `db:"skipped"       json:"skipped"`
+	// Tests which passed after a retry, they are part of the passes as well
+	RetryPasses int64 `db:"retry_passes" json:"retry-passes"`
+}
@@ -30,4 +30,10 @@ type Test struct {
 	FailedTestRuns int64     `                 json:"failed-test-runs"`
 	TotalTestRuns  int64     `                 json:"total-test-runs"`
 	FirstTotal     int64     `                 json:"first-total"`
+	// Failures of quarantined tests, they are not part of the failures
+	Quarantined int64 `json:"quarantined"`
+	// Tests which passed after a retry, they are part of the passes as well
+	RetryPasses int64 `db:"retry_passes" json:"retry-passes"`
+	// Number of results in the window with tests which passed after a retry
+	RetriedTestRuns int64 `json:"retried-test-runs"`
 }
@@ -0,0 +1,21 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+// TestAlias declares that a renamed suite or moved spec file is still the same test. A test is identified by
+// component, suite and file, with an alias the test results of the old suite and file belong to the new one.
+type TestAlias struct {
+	Id        int64  `db:"id"         json:"id"`
+	ProductId int64  `db:"product_id" json:"product-id"`
+	Component string `db:"component"  json:"component"`
+	OldSuite  string `db:"old_suite"  json:"old-suite"`
+	OldFile   string `db:"old_file"   json:"old-file"`
+	NewSuite  string `db:"new_suite"  json:"new-suite"`
+	NewFile   string `db:"new_file"   json:"new-file"`
+}
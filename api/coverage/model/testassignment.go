@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+// TestAssignment assigns the tests of a component, suite and file to an area and feature. It is applied to the
+// stored tests and to all future uploads which cannot be mapped otherwise.
+type TestAssignment struct {
+	Id        int64  `db:"id"         json:"id"`
+	ProductId int64  `db:"product_id" json:"product-id"`
+	Component string `db:"component"  json:"component"`
+	Suite     string `db:"suite"      json:"suite"`
+	File      string `db:"file"       json:"file"`
+	AreaId    int64  `db:"area_id"    json:"area-id"`
+	FeatureId int64  `db:"feature_id" json:"feature-id"`
+}
+
+// TestAssignmentFilter selects unassigned tests of a product to assign them all to the same area and feature.
+// Suite and file match if they contain the value, the component has to be equal.
+type TestAssignmentFilter struct {
+	Component string `json:"component"`
+	Suite     string `json:"suite"`
+	File      string `json:"file"`
+	AreaId    int64  `json:"area-id"`
+	FeatureId int64  `json:"feature-id"`
+}
@@ -0,0 +1,23 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+type TestCase struct {
+	Id           int64  `db:"id"            json:"id"`
+	TestId       int64  `db:"test_id"       json:"test-id"`
+	Title        string `db:"title"         json:"title"`
+	FullTitle    string `db:"full_title"    json:"full-title"`
+	State        string `db:"state"         json:"state"`
+	Duration     int64  `db:"duration"      json:"duration"`
+	ErrorMessage string `db:"error_message" json:"error-message"`
+	Stack        string `db:"stack"         json:"stack"`
+	Diff         string `db:"diff"          json:"diff"`
+	// Failures with the same signature very likely have the same root cause
+	Signature string `db:"signature" json:"signature"`
+}
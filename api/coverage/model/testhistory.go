@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// Status of a test result in the history of a test
+const (
+	TestPassed = "passed"
+	TestFailed = "failed"
+	// The result has no failures, but tests which passed only after a retry
+	TestPassedAfterRetry = "passed-after-retry"
+	// All tests of the result were skipped or pending
+	TestSkipped = "skipped"
+)
+
+// TestHistoryEntry is one result of a test. The duration is the sum of the durations of its test cases in ms.
+type TestHistoryEntry struct {
+	TestId      int64     `json:"test-id"`
+	RunId       int64     `json:"run-id"`
+	CommitSha   string    `json:"commit-sha"`
+	Branch      string    `json:"branch"`
+	Environment string    `json:"environment"`
+	TestRun     time.Time `json:"test-run"`
+	Status      string    `json:"status"`
+	Total       int64     `json:"total"`
+	Passes      int64     `json:"passes"`
+	Pending     int64     `json:"pending"`
+	Failures    int64     `json:"failures"`
+	Skipped     int64     `json:"skipped"`
+	RetryPasses int64     `json:"retry-passes"`
+	Duration    int64     `json:"duration"`
+	Url         string    `json:"url"`
+}
+
+// TestHistory contains the results of a test, the oldest first, and the stats computed from them
+type TestHistory struct {
+	Component string             `json:"component"`
+	Suite     string             `json:"suite"`
+	FileName  string             `json:"file-name"`
+	Runs      []TestHistoryEntry `json:"runs"`
+	// Number of the latest results with the same status as the latest result
+	CurrentStreak int64      `json:"current-streak"`
+	StreakStatus  string     `json:"streak-status"`
+	LastFailure   *time.Time `json:"last-failure"`
+	LastPass      *time.Time `json:"last-pass"`
+	// Failed results divided by all results
+	FailureRate float64 `json:"failure-rate"`
+	// Mean time between two failure onsets, i.e. a failed result whose previous result did not fail.
+	// 0 if there are less than two onsets.
+	MeanHoursBetweenFailures float64 `json:"mtbf-hours"`
+}
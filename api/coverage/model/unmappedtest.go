@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+import "time"
+
+// Status of an unmapped test in the triage queue
+const (
+	UnmappedPending  = "pending"
+	UnmappedAccepted = "accepted"
+	UnmappedRejected = "rejected"
+)
+
+// UnmappedTest is an unknown area and feature name uploaded for a product in strict mapping mode.
+// Once accepted, the area and feature ids are used for all tests with these names.
+type UnmappedTest struct {
+	Id          int64     `db:"id"          json:"id"`
+	ProductId   int64     `db:"product_id"  json:"product-id"`
+	Area        string    `db:"area"        json:"area"`
+	Feature     string    `db:"feature"     json:"feature"`
+	Status      string    `db:"status"      json:"status"`
+	AreaId      int64     `db:"area_id"     json:"area-id"`
+	FeatureId   int64     `db:"feature_id"  json:"feature-id"`
+	Occurrences int64     `db:"occurrences" json:"occurrences"`
+	FirstSeen   time.Time `db:"first_seen"  json:"first-seen"`
+	LastSeen    time.Time `db:"last_seen"   json:"last-seen"`
+}
+
+// Area and feature an unmapped test is mapped to when it is accepted
+type UnmappedTestMapping struct {
+	AreaId    int64 `json:"area-id"`
+	FeatureId int64 `json:"feature-id"`
+}
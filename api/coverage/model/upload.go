@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package model
+
+// Status of a test result of an upload
+const (
+	UploadCreated = "created"
+	// The test result was uploaded before and is not stored again
+	UploadDuplicate = "duplicate"
+	UploadFailed    = "failed"
+	// The test result was processed, but not stored, because another test result of the upload failed
+	UploadRolledBack = "rolled-back"
+	// The test result was not processed, because another test result of the upload failed
+	UploadNotProcessed = "not-processed"
+)
+
+// Error codes of a failed test result
+const (
+	UploadErrorDuplicateCheck = "DUPLICATE_CHECK_FAILED"
+	UploadErrorMapping        = "MAPPING_FAILED"
+	UploadErrorRun            = "RUN_FAILED"
+	UploadErrorInsert         = "INSERT_FAILED"
+	UploadErrorInternal       = "INTERNAL_ERROR"
+)
+
+// Types of entities created by an upload
+const (
+	UploadEntityRun     = "run"
+	UploadEntityArea    = "area"
+	UploadEntityFeature = "feature"
+)
+
+// UploadResponse is the result of an upload. Without partial mode, either all test results are stored or none.
+type UploadResponse struct {
+	// ID of the run of the upload, 0 if no test result was stored
+	RunId      int64          `json:"run-id"`
+	Partial    bool           `json:"partial"`
+	Created    int            `json:"created"`
+	Duplicates int            `json:"duplicates"`
+	Failed     int            `json:"failed"`
+	Results    []UploadResult `json:"results"`
+}
+
+// UploadResult is the status of one test result of an upload
+type UploadResult struct {
+	Uuid      string `json:"uuid"`
+	Component string `json:"component"`
+	Suite     string `json:"suite"`
+	FileName  string `json:"file-name"`
+	Status    string `json:"status"`
+	// The IDs are only set if the test result is stored. The ID of the test result is needed to upload attachments.
+	TestId int64 `json:"test-id,omitempty"`
+	// Area and feature the test result is mapped to, 0 if it could not be mapped
+	AreaId     int64 `json:"area-id"`
+	FeatureId  int64 `json:"feature-id"`
+	UnmappedId int64 `json:"unmapped-id,omitempty"`
+	// Entities created while storing the test result
+	CreatedEntities []UploadEntity `json:"created-entities"`
+	ErrorCode       string         `json:"error-code,omitempty"`
+	Error           string         `json:"error,omitempty"`
+}
+
+// UploadEntity is an entity created by an upload
+type UploadEntity struct {
+	Type string `json:"type"`
+	Id   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
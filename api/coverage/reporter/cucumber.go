@@ -0,0 +1,234 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cucumberAreaTag    = "@area:"
+	cucumberFeatureTag = "@feature:"
+)
+
+type CucumberFeature struct {
+	Uri      string            `json:"uri"`
+	Id       string            `json:"id"`
+	Name     string            `json:"name"`
+	Tags     []CucumberTag     `json:"tags"`
+	Elements []CucumberElement `json:"elements"`
+}
+
+type CucumberTag struct {
+	Name string `json:"name"`
+}
+
+// An element is either a scenario or the background which is executed before the following scenario
+type CucumberElement struct {
+	Id             string         `json:"id"`
+	Type           string         `json:"type"`
+	Name           string         `json:"name"`
+	StartTimestamp string         `json:"start_timestamp"`
+	Tags           []CucumberTag  `json:"tags"`
+	Before         []CucumberStep `json:"before"`
+	Steps          []CucumberStep `json:"steps"`
+	After          []CucumberStep `json:"after"`
+}
+
+type CucumberStep struct {
+	Keyword string             `json:"keyword"`
+	Name    string             `json:"name"`
+	Result  CucumberStepResult `json:"result"`
+}
+
+type CucumberStepResult struct {
+	Status       string `json:"status"`
+	Duration     int64  `json:"duration"`
+	ErrorMessage string `json:"error_message"`
+}
+
+const CucumberFormat = "cucumber"
+
+type cucumberReporter struct{}
+
+func init() {
+	Register(cucumberReporter{})
+}
+
+func (cucumberReporter) Name() string {
+	return CucumberFormat
+}
+
+// A Cucumber JSON report is an array of Gherkin features, each with its elements
+func (cucumberReporter) Detect(body []byte) bool {
+	var features []map[string]json.RawMessage
+	if err := json.Unmarshal(body, &features); err != nil || len(features) == 0 {
+		return false
+	}
+	_, hasElements := features[0]["elements"]
+	return hasElements
+}
+
+// Reads a Cucumber JSON report
+func (cucumberReporter) Parse(body []byte) ([]TestResult, error) {
+	var features []CucumberFeature
+	if err := json.Unmarshal(body, &features); err != nil {
+		return nil, fmt.Errorf("error parsing Cucumber JSON: %w", err)
+	}
+	return getTestResultFromCucumber(features)
+}
+
+// Every Gherkin feature is mapped to one test result and every scenario counts as one test. Area and feature are taken
+// from the tags @area:{area} and @feature:{feature}. Without tags, the feature name is used, either in the format
+// {area}|{feature}|{suite} or as feature name only. Tags of a scenario win over the tags of its Gherkin feature, the
+// scenarios mapped to another area or feature are a separate test result with the area and feature in the suite name.
+func getTestResultFromCucumber(features []CucumberFeature) ([]TestResult, error) {
+	var results []TestResult
+	for _, f := range features {
+		area, feature, suite := splitSuiteTitle(f.Name)
+		if area == "" && feature == "" {
+			feature = f.Name
+		}
+		if value, ok := cucumberTagValue(f.Tags, cucumberAreaTag); ok {
+			area = value
+		}
+		if value, ok := cucumberTagValue(f.Tags, cucumberFeatureTag); ok {
+			feature = value
+		}
+
+		// Scenarios grouped by their area and feature, in the order of the report
+		var groups []*cucumberResult
+		var background []CucumberStep
+		for _, e := range f.Elements {
+			if e.Type == "background" {
+				background = e.Steps
+				continue
+			}
+			g := cucumberGroup(&groups, f, e, area, feature, suite)
+			g.addCase(cucumberTestCase(f, background, e))
+			addCucumberTags(&g.TestResult, e.Tags)
+			if start, err := time.Parse(time.RFC3339Nano, e.StartTimestamp); err == nil && start.After(g.TestRun) {
+				g.TestRun = start
+			}
+			// The id is derived from the content of the report only, so uploading the same report again is detected
+			g.content = append(g.content, e.Id, e.StartTimestamp)
+			background = nil
+		}
+
+		for _, g := range groups {
+			if g.Total == 0 {
+				continue
+			}
+			if g.TestRun.IsZero() {
+				g.TestRun = time.Now().UTC()
+			}
+			for _, tc := range g.Cases {
+				g.content = append(g.content, tc.FullTitle, tc.State, strconv.FormatInt(tc.Duration, 10), tc.ErrorMessage)
+			}
+			g.Uuid = deriveUuid(append([]string{f.Uri, f.Id, g.Area, g.Feature}, g.content...)...)
+			results = append(results, g.TestResult)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("Cucumber report does not contain any scenarios")
+	}
+	return results, nil
+}
+
+// Test result of the scenarios of a Gherkin feature with the same area and feature
+type cucumberResult struct {
+	TestResult
+	// Values of the report the id is derived from
+	content []string
+}
+
+// Returns the test result of the area and feature of the scenario, it is added if the scenario is the first one
+func cucumberGroup(groups *[]*cucumberResult, f CucumberFeature, e CucumberElement, area, feature, suite string) *cucumberResult {
+	scenarioArea, scenarioFeature := area, feature
+	if value, ok := cucumberTagValue(e.Tags, cucumberAreaTag); ok {
+		scenarioArea = value
+	}
+	if value, ok := cucumberTagValue(e.Tags, cucumberFeatureTag); ok {
+		scenarioFeature = value
+	}
+	for _, g := range *groups {
+		if g.Area == scenarioArea && g.Feature == scenarioFeature {
+			return g
+		}
+	}
+
+	g := &cucumberResult{TestResult: TestResult{File: f.Uri, Area: scenarioArea, Feature: scenarioFeature, Suite: suite}}
+	if scenarioArea != area || scenarioFeature != feature {
+		// The suite, file and component identify a test, so the suite has to differ from the one of the Gherkin feature
+		g.Suite = fmt.Sprintf("%s (%s|%s)", suite, scenarioArea, scenarioFeature)
+	}
+	addCucumberTags(&g.TestResult, f.Tags)
+	*groups = append(*groups, g)
+	return g
+}
+
+// Adds the tags which the test result does not have yet
+func addCucumberTags(tr *TestResult, tags []CucumberTag) {
+	for _, tag := range tags {
+		if !slices.Contains(tr.Tags, tag.Name) {
+			tr.Tags = append(tr.Tags, tag.Name)
+		}
+	}
+}
+
+// The state of a scenario is derived from the status of all its steps and hooks, including the background steps.
+// A failed step wins over undefined or pending steps, those win over skipped steps.
+func cucumberTestCase(f CucumberFeature, background []CucumberStep, e CucumberElement) TestCase {
+	tc := TestCase{
+		Title:     e.Name,
+		FullTitle: strings.TrimSpace(f.Name + " " + e.Name),
+		State:     StatePassed,
+	}
+	// The duration is reported in ns
+	var duration int64
+	for _, steps := range [][]CucumberStep{e.Before, background, e.Steps, e.After} {
+		for _, step := range steps {
+			duration += step.Result.Duration
+			switch step.Result.Status {
+			case "failed", "ambiguous":
+				if tc.State != StateFailed {
+					tc.State = StateFailed
+					tc.ErrorMessage, _, _ = strings.Cut(step.Result.ErrorMessage, "\n")
+					tc.Stack = step.Result.ErrorMessage
+				}
+			case "undefined", "pending":
+				if tc.State != StateFailed {
+					tc.State = StatePending
+				}
+			case "skipped":
+				if tc.State == StatePassed {
+					tc.State = StateSkipped
+				}
+			}
+		}
+	}
+	tc.Duration = duration / int64(time.Millisecond)
+	return tc
+}
+
+// Returns the value of the first tag with the specified prefix, e.g. Checkout for @area:Checkout
+func cucumberTagValue(tags []CucumberTag, prefix string) (string, bool) {
+	for _, tag := range tags {
+		if value, ok := strings.CutPrefix(tag.Name, prefix); ok && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseCucumber(t *testing.T) {
+	results := parseFixture(t, CucumberFormat, readFixture(t, "cucumber.json"))
+
+	compareResults(t, results, []resultSummary{
+		{Area: "Checkout", Feature: "Payment", Suite: "Credit card", File: "features/payment.feature", Total: 3, Passes: 1,
+			Pending: 1, Failures: 1, Duration: 6},
+		// Scenario tags win over the feature name, the suite differs from the one of the Gherkin feature
+		{Area: "Account", Feature: "Login", Suite: "Credit card (Account|Login)", File: "features/payment.feature", Total: 1,
+			Passes: 1, Duration: 4},
+		// The feature name is the feature, the area is taken from the tag
+		{Area: "Catalog", Feature: "Search", Suite: "Search", File: "features/search.feature", Total: 1, Skipped: 1},
+	})
+
+	if want := time.Date(2026, 3, 1, 10, 0, 1, 0, time.UTC); !results[0].TestRun.Equal(want) {
+		t.Errorf("test run %s, want %s", results[0].TestRun, want)
+	}
+	if want := []string{StatePassed, StateFailed, StatePending}; !slices.Equal(caseStates(results[0]), want) {
+		t.Errorf("states %v, want %v", caseStates(results[0]), want)
+	}
+	failed := results[0].Cases[1]
+	if failed.ErrorMessage != "Expected 402" || failed.Stack != "Expected 402\n    at payment.steps.js:12:5" {
+		t.Errorf("failed test case %+v", failed)
+	}
+	if want := []string{"@smoke", "@area:Account", "@feature:Login"}; !slices.Equal(results[1].Tags, want) {
+		t.Errorf("tags %v, want %v", results[1].Tags, want)
+	}
+}
+
+func TestCucumberUuid(t *testing.T) {
+	body := readFixture(t, "cucumber.json")
+	first := parseFixture(t, CucumberFormat, body)
+	// The scenarios of the search feature have no start timestamp, the current time must not change the id
+	time.Sleep(time.Millisecond)
+	second := parseFixture(t, CucumberFormat, body)
+
+	uuids := map[string]bool{}
+	for i := range first {
+		if first[i].Uuid != second[i].Uuid {
+			t.Errorf("uuid of result %d changed from %s to %s", i, first[i].Uuid, second[i].Uuid)
+		}
+		uuids[first[i].Uuid] = true
+	}
+	if len(uuids) != len(first) {
+		t.Errorf("%d different uuids for %d results", len(uuids), len(first))
+	}
+}
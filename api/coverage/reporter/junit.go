@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type JUnitTestSuites struct {
+	XMLName   xml.Name         `xml:"testsuites"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Suites    []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	File      string           `xml:"file,attr"`
+	Hostname  string           `xml:"hostname,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Time      string           `xml:"time,attr"`
+	TestCases []JUnitTestCase  `xml:"testcase"`
+	Suites    []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure"`
+	Error     *JUnitMessage `xml:"error"`
+	Skipped   *JUnitMessage `xml:"skipped"`
+	// Failed attempts of a test which passed when it was rerun (Maven Surefire)
+	FlakyFailures []JUnitMessage `xml:"flakyFailure"`
+	FlakyErrors   []JUnitMessage `xml:"flakyError"`
+}
+
+type JUnitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Timestamp formats used by the different JUnit producers (Surefire, pytest, go-junit-report, ...)
+var junitTimestampFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+}
+
+const JUnitFormat = "junit"
+
+type junitReporter struct{}
+
+func init() {
+	Register(junitReporter{})
+}
+
+func (junitReporter) Name() string {
+	return JUnitFormat
+}
+
+// A JUnit report is an XML document with <testsuites> or <testsuite> as root element
+func (junitReporter) Detect(body []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local == "testsuites" || start.Name.Local == "testsuite"
+		}
+	}
+}
+
+// Reads a JUnit XML report. The root element can either be <testsuites> or a single <testsuite>.
+func (junitReporter) Parse(body []byte) ([]TestResult, error) {
+	var root JUnitTestSuites
+	if err := xml.Unmarshal(body, &root); err != nil {
+		// Not a <testsuites> document, try a single <testsuite>
+		var suite JUnitTestSuite
+		if err := xml.Unmarshal(body, &suite); err != nil {
+			return nil, fmt.Errorf("error parsing JUnit XML: %w", err)
+		}
+		root.Suites = []JUnitTestSuite{suite}
+	}
+
+	var results []TestResult
+	for _, suite := range flattenJUnitSuites(root.Suites) {
+		if len(suite.TestCases) == 0 {
+			continue
+		}
+		tr := TestResult{}
+		tr.Area, tr.Feature, tr.Suite = splitSuiteTitle(suite.Name)
+		tr.File = junitFileName(suite)
+		tr.TestRun = junitEndTime(suite, root.Timestamp)
+
+		for _, tc := range suite.TestCases {
+			tr.addCase(junitTestCase(tc))
+		}
+		tr.Uuid = junitUuid(suite, root.Timestamp, tr)
+		results = append(results, tr)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("JUnit report does not contain any test cases")
+	}
+	return results, nil
+}
+
+func junitTestCase(tc JUnitTestCase) TestCase {
+	c := TestCase{
+		Title:     tc.Name,
+		FullTitle: tc.Name,
+		State:     StatePassed,
+	}
+	if tc.ClassName != "" {
+		c.FullTitle = tc.ClassName + " " + tc.Name
+	}
+	if seconds, err := strconv.ParseFloat(tc.Time, 64); err == nil {
+		c.Duration = int64(seconds * 1000)
+	}
+
+	failure := tc.Failure
+	if failure == nil {
+		failure = tc.Error
+	}
+	switch {
+	case failure != nil:
+		c.State = StateFailed
+		c.ErrorMessage = failure.Message
+		if c.ErrorMessage == "" {
+			c.ErrorMessage = failure.Type
+		}
+		c.Stack = strings.TrimSpace(failure.Text)
+	case tc.Skipped != nil:
+		c.State = StateSkipped
+	case len(tc.FlakyFailures) > 0 || len(tc.FlakyErrors) > 0:
+		c.State = StatePassedAfterRetry
+	}
+	return c
+}
+
+// The id is derived from the content of the report only, so uploading the same report again is detected, also if it
+// has no timestamp and the current time is used as test run.
+func junitUuid(suite JUnitTestSuite, rootTimestamp string, tr TestResult) string {
+	values := []string{suite.Name, tr.File, suite.Hostname, suite.Timestamp, rootTimestamp, suite.Time}
+	for _, tc := range tr.Cases {
+		values = append(values, tc.FullTitle, tc.State, strconv.FormatInt(tc.Duration, 10), tc.ErrorMessage)
+	}
+	return deriveUuid(values...)
+}
+
+// Some producers nest <testsuite> elements, e.g. one per package containing one per class.
+// Every suite with test cases is treated as its own result.
+func flattenJUnitSuites(suites []JUnitTestSuite) []JUnitTestSuite {
+	var flat []JUnitTestSuite
+	for _, suite := range suites {
+		flat = append(flat, suite)
+		flat = append(flat, flattenJUnitSuites(suite.Suites)...)
+	}
+	return flat
+}
+
+// The file is not a mandatory attribute, so fall back to the test case file or class name.
+func junitFileName(suite JUnitTestSuite) string {
+	if suite.File != "" {
+		return suite.File
+	}
+	tc := suite.TestCases[0]
+	if tc.File != "" {
+		return tc.File
+	}
+	if tc.ClassName != "" {
+		return tc.ClassName
+	}
+	return suite.Name
+}
+
+// Returns the end time of the suite run, which is the timestamp plus the duration. If the suite has
+// no timestamp, the one of the <testsuites> element or the current time is used.
+func junitEndTime(suite JUnitTestSuite, rootTimestamp string) time.Time {
+	start, ok := parseJUnitTimestamp(suite.Timestamp)
+	if !ok {
+		start, ok = parseJUnitTimestamp(rootTimestamp)
+	}
+	if !ok {
+		return time.Now().UTC()
+	}
+	if seconds, err := strconv.ParseFloat(suite.Time, 64); err == nil {
+		start = start.Add(time.Duration(seconds * float64(time.Second)))
+	}
+	return start
+}
+
+func parseJUnitTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, format := range junitTimestampFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJUnit(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []resultSummary
+	}{
+		{"junit.xml", []resultSummary{
+			{Area: "Checkout", Feature: "Payment", Suite: "Credit card", File: "tests/test_payment.py", Total: 4, Passes: 2,
+				Failures: 1, Skipped: 1, RetryPasses: 1, Duration: 1625},
+			// Nested suites, the file is the class name
+			{Suite: "LoginTest", File: "com.example.LoginTest", Total: 2, Passes: 1, Failures: 1, Duration: 300},
+		}},
+		// A single <testsuite> as root element
+		{"junit-testsuite.xml", []resultSummary{
+			{Suite: "api", File: "api/product_test.go", Total: 1, Passes: 1, Duration: 2000},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			compareResults(t, parseFixture(t, JUnitFormat, readFixture(t, tt.fixture)), tt.want)
+		})
+	}
+}
+
+func TestJUnitTestCases(t *testing.T) {
+	results := parseFixture(t, JUnitFormat, readFixture(t, "junit.xml"))
+
+	want := []string{StatePassed, StateFailed, StateSkipped, StatePassedAfterRetry}
+	if got := caseStates(results[0]); !slices.Equal(got, want) {
+		t.Errorf("states %v, want %v", got, want)
+	}
+	failed := results[0].Cases[1]
+	if failed.FullTitle != "tests.test_payment declines expired cards" || failed.ErrorMessage != "AssertionError: expected 402" ||
+		failed.Duration != 750 {
+		t.Errorf("failed test case %+v", failed)
+	}
+	if !strings.HasPrefix(failed.Stack, "Traceback (most recent call last):") {
+		t.Errorf("stack %q", failed.Stack)
+	}
+	// Without message the type of the error is the message
+	if got := results[1].Cases[1].ErrorMessage; got != "NullPointerException" {
+		t.Errorf("error message %q, want NullPointerException", got)
+	}
+}
+
+func TestJUnitTestRun(t *testing.T) {
+	results := parseFixture(t, JUnitFormat, readFixture(t, "junit-testsuite.xml"))
+	// The end of the suite is the timestamp plus the duration
+	if want := time.Date(2026, 3, 1, 10, 0, 2, 0, time.UTC); !results[0].TestRun.Equal(want) {
+		t.Errorf("test run %s, want %s", results[0].TestRun, want)
+	}
+}
+
+func TestJUnitUuid(t *testing.T) {
+	body := readFixture(t, "junit.xml")
+	first := parseFixture(t, JUnitFormat, body)
+	// The report has no timestamp, the current time of the upload must not change the id
+	time.Sleep(time.Millisecond)
+	second := parseFixture(t, JUnitFormat, body)
+	for i := range first {
+		if first[i].Uuid != second[i].Uuid {
+			t.Errorf("uuid of result %d changed from %s to %s", i, first[i].Uuid, second[i].Uuid)
+		}
+	}
+	if first[0].Uuid == first[1].Uuid {
+		t.Errorf("results have the same uuid %s", first[0].Uuid)
+	}
+
+	changed := parseFixture(t, JUnitFormat, bytes.Replace(body, []byte(`time="0.5"`), []byte(`time="0.6"`), 1))
+	if changed[0].Uuid == first[0].Uuid {
+		t.Error("uuid did not change with the content of the report")
+	}
+}
@@ -9,11 +9,9 @@ LICENSE file in the root directory of this source tree.
 package reporter
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
-
-	"github.com/gin-gonic/gin"
 )
 
 type Mocha struct {
@@ -38,23 +36,59 @@ type Results struct {
 
 type Suite struct {
 	Title  string  `json:"title"`
+	Uuid   string  `json:"uuid"`
 	Tests  []Test  `json:"tests"`
 	Suites []Suite `json:"suites"`
 }
 
 type Test struct {
-	Pass    bool `json:"pass"`
-	Fail    bool `json:"fail"`
-	Pending bool `json:"pending"`
-	Skipped bool `json:"skipped"`
+	Title     string `json:"title"`
+	FullTitle string `json:"fullTitle"`
+	Duration  int64  `json:"duration"`
+	Pass      bool   `json:"pass"`
+	Fail      bool   `json:"fail"`
+	Pending   bool   `json:"pending"`
+	Skipped   bool   `json:"skipped"`
+	Err       Err    `json:"err"`
+	// Number of the attempt, set by Mocha and Cypress if tests are retried
+	CurrentRetry int `json:"currentRetry"`
+}
+
+// The error of a failed test. Mochawesome uses estack, the Mocha JSON reporter stack.
+// The diff of the expected and actual value is set by mochawesome for failed assertions.
+type Err struct {
+	Message string `json:"message"`
+	Estack  string `json:"estack"`
+	Stack   string `json:"stack"`
+	Diff    string `json:"diff"`
+}
+
+const MochaFormat = "mocha"
+
+type mochaReporter struct{}
+
+func init() {
+	Register(mochaReporter{})
+}
+
+func (mochaReporter) Name() string {
+	return MochaFormat
+}
+
+// A Mocha summary report (mochawesome) is a JSON object with the stats and the results
+func (mochaReporter) Detect(body []byte) bool {
+	keys := JSONObjectKeys(body)
+	_, hasStats := keys["stats"]
+	_, hasResults := keys["results"]
+	return hasStats && hasResults
 }
 
-// Iterate through the mocha report and get all the needed data. Currently it is only supported, that the results section contains only one suite entry.
-func ReadMochaResultFromContext(c *gin.Context) ([]TestResult, error) {
+// Iterate through the mocha report and get all the needed data. Every top level suite of a result is
+// mapped to its own test result, so a spec file can contain tests for more than one area and feature.
+func (mochaReporter) Parse(body []byte) ([]TestResult, error) {
 	var m Mocha
-	err := c.BindJSON(&m)
-	if err != nil {
-		return nil, fmt.Errorf("error during BindJSON(): %w", err)
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("error parsing Mocha JSON: %w", err)
 	}
 	return getTestResultFromMocha(m)
 }
@@ -67,61 +101,68 @@ func getTestResultFromMocha(m Mocha) ([]TestResult, error) {
 
 	var results []TestResult
 	for _, result := range m.Results {
-		tr := extractSuiteDetails(result.Suites)
-		tr.File = result.File
-		tr.Uuid = result.Uuid
-		tr.TestRun = parsedEndTime
-
-		total, passes, pending, failures, skipped := countTests(result.Suites)
-		tr.Total = total
-		tr.Passes = passes
-		tr.Pending = pending
-		tr.Failures = failures
-		tr.Skipped = skipped
-		results = append(results, tr)
+		for i, suite := range result.Suites {
+			tr := TestResult{}
+			tr.Area, tr.Feature, tr.Suite = splitSuiteTitle(suite.Title)
+			tr.File = result.File
+			tr.Tags = titleTags(suite.Title)
+			tr.Uuid = suiteUuid(result, i)
+			tr.TestRun = parsedEndTime
+
+			for _, test := range appendSuiteTests(suite) {
+				tr.addCase(mochaTestCase(test))
+			}
+			results = append(results, tr)
+		}
 	}
 	return results, nil
 }
 
-func extractSuiteDetails(suites []Suite) TestResult {
-	tr := TestResult{}
-	if len(suites) > 0 {
-		if parts := strings.Split(suites[0].Title, "|"); len(parts) > 2 {
-			tr.Area = parts[0]
-			tr.Feature = parts[1]
-			tr.Suite = parts[2]
-		} else {
-			tr.Suite = suites[0].Title
-		}
+// The first suite uses the uuid of the result, as it was done before a result could contain more
+// than one suite. This way already uploaded reports are still detected as duplicates.
+func suiteUuid(result Results, index int) string {
+	switch {
+	case index == 0:
+		return result.Uuid
+	case result.Suites[index].Uuid != "":
+		return result.Suites[index].Uuid
+	default:
+		return fmt.Sprintf("%s-%d", result.Uuid, index)
 	}
-	return tr
 }
 
-func countTests(suites []Suite) (total, passes, pending, failures, skipped int) {
-	for _, suite := range suites {
-		tests := appendSuiteTests(suite)
-
-		for _, test := range tests {
-			total++
-			switch {
-			case test.Pass:
-				passes++
-			case test.Fail:
-				failures++
-			case test.Skipped:
-				skipped++
-			case test.Pending:
-				pending++
-			}
+func mochaTestCase(test Test) TestCase {
+	tc := TestCase{
+		Title:     test.Title,
+		FullTitle: test.FullTitle,
+		Duration:  test.Duration,
+	}
+	switch {
+	case test.Pass && test.CurrentRetry > 0:
+		tc.State = StatePassedAfterRetry
+	case test.Pass:
+		tc.State = StatePassed
+	case test.Fail:
+		tc.State = StateFailed
+		tc.ErrorMessage = test.Err.Message
+		tc.Stack = test.Err.Estack
+		if tc.Stack == "" {
+			tc.Stack = test.Err.Stack
 		}
+		tc.Diff = test.Err.Diff
+	case test.Skipped:
+		tc.State = StateSkipped
+	case test.Pending:
+		tc.State = StatePending
 	}
-	return
+	return tc
 }
 
+// Returns the tests of the suite and of all nested suites
 func appendSuiteTests(suite Suite) []Test {
 	tests := suite.Tests
 	for _, subSuite := range suite.Suites {
-		tests = append(tests, subSuite.Tests...)
+		tests = append(tests, appendSuiteTests(subSuite)...)
 	}
 	return tests
 }
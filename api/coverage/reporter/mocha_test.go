@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseMocha(t *testing.T) {
+	results := parseFixture(t, MochaFormat, readFixture(t, "mocha.json"))
+
+	compareResults(t, results, []resultSummary{
+		// The tests of the nested suites are part of the top level suite
+		{Area: "Checkout", Feature: "Payment", Suite: "Credit card @smoke", File: "cypress/e2e/checkout.cy.js", Total: 4,
+			Passes: 2, Pending: 1, Failures: 1, RetryPasses: 1, Duration: 600},
+		{Area: "Checkout", Feature: "Shipping", Suite: "Address", File: "cypress/e2e/checkout.cy.js", Total: 1, Passes: 1,
+			Duration: 50},
+		{Area: "Checkout", Feature: "Shipping", Suite: "Express", File: "cypress/e2e/checkout.cy.js", Total: 1, Skipped: 1},
+		{Suite: "Login", File: "cypress/e2e/login.cy.js", Total: 1, Passes: 1, Duration: 70},
+	})
+
+	if want := time.Date(2026, 3, 1, 10, 0, 5, 0, time.UTC); !results[0].TestRun.Equal(want) {
+		t.Errorf("test run %s, want %s", results[0].TestRun, want)
+	}
+	if want := []string{StatePassed, StateFailed, StatePassedAfterRetry, StatePending}; !slices.Equal(caseStates(results[0]), want) {
+		t.Errorf("states %v, want %v", caseStates(results[0]), want)
+	}
+	failed := results[0].Cases[1]
+	if failed.ErrorMessage != "AssertionError: expected 402" || failed.Stack == "" || failed.Diff != "- 402\n+ 200" {
+		t.Errorf("failed test case %+v", failed)
+	}
+	if want := []string{"@smoke"}; !slices.Equal(results[0].Tags, want) {
+		t.Errorf("tags %v, want %v", results[0].Tags, want)
+	}
+}
+
+func TestMochaUuid(t *testing.T) {
+	results := parseFixture(t, MochaFormat, readFixture(t, "mocha.json"))
+
+	// The first suite keeps the uuid of the result, so reports uploaded before are still duplicates
+	want := []string{"result-1", "suite-2", "result-1-2", "result-2"}
+	var got []string
+	for _, tr := range results {
+		got = append(got, tr.Uuid)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("uuids %v, want %v", got, want)
+	}
+}
+
+func TestParseMochaInvalidEndTime(t *testing.T) {
+	r, _ := Get(MochaFormat)
+	if _, err := r.Parse([]byte(`{"stats": {"end": "yesterday"}, "results": []}`)); err == nil {
+		t.Error("report with invalid end time is parsed")
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Playwright struct {
+	Suites []PlaywrightSuite `json:"suites"`
+	Stats  PlaywrightStats   `json:"stats"`
+}
+
+type PlaywrightStats struct {
+	StartTime  string  `json:"startTime"`
+	Duration   float64 `json:"duration"`
+	Expected   int     `json:"expected"`
+	Unexpected int     `json:"unexpected"`
+	Flaky      int     `json:"flaky"`
+	Skipped    int     `json:"skipped"`
+}
+
+type PlaywrightSuite struct {
+	Title  string            `json:"title"`
+	File   string            `json:"file"`
+	Specs  []PlaywrightSpec  `json:"specs"`
+	Suites []PlaywrightSuite `json:"suites"`
+}
+
+type PlaywrightSpec struct {
+	Title string           `json:"title"`
+	Tags  []string         `json:"tags"`
+	Tests []PlaywrightTest `json:"tests"`
+}
+
+// A PlaywrightTest is the execution of a spec in one project, with one result per attempt
+type PlaywrightTest struct {
+	ProjectName    string                 `json:"projectName"`
+	ExpectedStatus string                 `json:"expectedStatus"`
+	Status         string                 `json:"status"`
+	Results        []PlaywrightTestResult `json:"results"`
+}
+
+type PlaywrightTestResult struct {
+	Status   string           `json:"status"`
+	Retry    int              `json:"retry"`
+	Duration float64          `json:"duration"`
+	Error    *PlaywrightError `json:"error"`
+}
+
+type PlaywrightError struct {
+	Message string `json:"message"`
+	Stack   string `json:"stack"`
+}
+
+// A test of a spec together with the titles of the spec and its describe blocks
+type playwrightCase struct {
+	title     string
+	fullTitle string
+	tags      []string
+	test      PlaywrightTest
+}
+
+const PlaywrightFormat = "playwright"
+
+type playwrightReporter struct{}
+
+func init() {
+	Register(playwrightReporter{})
+}
+
+func (playwrightReporter) Name() string {
+	return PlaywrightFormat
+}
+
+// A report of the Playwright JSON reporter is a JSON object with the config and the suites
+func (playwrightReporter) Detect(body []byte) bool {
+	keys := JSONObjectKeys(body)
+	_, hasConfig := keys["config"]
+	_, hasSuites := keys["suites"]
+	return hasConfig && hasSuites
+}
+
+// Reads a report of the Playwright JSON reporter
+func (playwrightReporter) Parse(body []byte) ([]TestResult, error) {
+	var p Playwright
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("error parsing Playwright JSON: %w", err)
+	}
+	return getTestResultFromPlaywright(p)
+}
+
+// The top level suites of a Playwright report are the spec files, their child suites the describe blocks.
+// Every top level describe block is mapped to one test result per project, using the title format
+// {area}|{feature}|{suite}. Tests outside of a describe block are grouped under the file name.
+func getTestResultFromPlaywright(p Playwright) ([]TestResult, error) {
+	if len(p.Suites) == 0 {
+		return nil, fmt.Errorf("Playwright report does not contain any suites")
+	}
+
+	testRun, err := playwrightEndTime(p.Stats)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TestResult
+	for _, file := range p.Suites {
+		fileName := file.File
+		if fileName == "" {
+			fileName = file.Title
+		}
+
+		var groups []PlaywrightSuite
+		if len(file.Specs) > 0 {
+			groups = append(groups, PlaywrightSuite{Title: file.Title, Specs: file.Specs})
+		}
+		groups = append(groups, file.Suites...)
+
+		for _, group := range groups {
+			area, feature, suite := splitSuiteTitle(group.Title)
+
+			// One result per project, in the order the projects appear in the report
+			byProject := make(map[string]*TestResult)
+			var projects []string
+			for _, pc := range collectPlaywrightTests(group, "") {
+				test := pc.test
+				tr, ok := byProject[test.ProjectName]
+				if !ok {
+					tr = &TestResult{
+						Area:      area,
+						Feature:   feature,
+						Suite:     suite,
+						File:      fileName,
+						Component: test.ProjectName,
+						TestRun:   testRun,
+					}
+					byProject[test.ProjectName] = tr
+					projects = append(projects, test.ProjectName)
+				}
+				tr.addCase(playwrightTestCase(pc))
+				tr.Tags = appendMissing(tr.Tags, pc.tags...)
+			}
+			for _, project := range projects {
+				tr := byProject[project]
+				tr.Uuid = playwrightUuid(p.Stats, fileName, group.Title, *tr)
+				results = append(results, *tr)
+			}
+		}
+	}
+	return results, nil
+}
+
+// The id is derived from the content of the report only, so uploading the same report again is detected, also if it
+// has no start time and the current time is used as test run.
+func playwrightUuid(stats PlaywrightStats, fileName string, groupTitle string, tr TestResult) string {
+	values := []string{stats.StartTime, strconv.FormatFloat(stats.Duration, 'f', -1, 64), fileName, groupTitle, tr.Component}
+	for _, tc := range tr.Cases {
+		values = append(values, tc.FullTitle, tc.State, strconv.FormatInt(tc.Duration, 10), tc.ErrorMessage)
+	}
+	return deriveUuid(values...)
+}
+
+// Returns all tests of the suite including the ones of nested describe blocks
+func collectPlaywrightTests(suite PlaywrightSuite, parentTitle string) []playwrightCase {
+	title := strings.TrimSpace(parentTitle + " " + suite.Title)
+	var cases []playwrightCase
+	for _, spec := range suite.Specs {
+		for _, test := range spec.Tests {
+			cases = append(cases, playwrightCase{
+				title:     spec.Title,
+				fullTitle: strings.TrimSpace(title + " " + spec.Title),
+				tags:      spec.Tags,
+				test:      test,
+			})
+		}
+	}
+	for _, subSuite := range suite.Suites {
+		cases = append(cases, collectPlaywrightTests(subSuite, title)...)
+	}
+	return cases
+}
+
+// The status of a test is already the outcome over all retries, so a test is counted only once.
+// A flaky test failed at first but passed on a retry.
+func playwrightTestCase(pc playwrightCase) TestCase {
+	tc := TestCase{
+		Title:     pc.title,
+		FullTitle: pc.fullTitle,
+	}
+	switch pc.test.Status {
+	case "expected":
+		if pc.test.ExpectedStatus == "skipped" {
+			tc.State = StatePending
+		} else {
+			tc.State = StatePassed
+		}
+	case "flaky":
+		tc.State = StatePassedAfterRetry
+	case "unexpected":
+		tc.State = StateFailed
+	case "skipped":
+		tc.State = StatePending
+	default:
+		tc.State = StateSkipped
+	}
+
+	// The last attempt is the one that decided the status
+	if n := len(pc.test.Results); n > 0 {
+		last := pc.test.Results[n-1]
+		tc.Duration = int64(last.Duration)
+		if tc.State == StateFailed && last.Error != nil {
+			tc.ErrorMessage = last.Error.Message
+			tc.Stack = last.Error.Stack
+		}
+	}
+	return tc
+}
+
+// Appends the values which are not yet part of the slice
+func appendMissing(values []string, add ...string) []string {
+	for _, v := range add {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Returns the end time of the test run. The Playwright stats contain the start time and the duration in ms.
+func playwrightEndTime(stats PlaywrightStats) (time.Time, error) {
+	if stats.StartTime == "" {
+		return time.Now().UTC(), nil
+	}
+	start, err := time.Parse(time.RFC3339Nano, stats.StartTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing start time: %w", err)
+	}
+	return start.Add(time.Duration(stats.Duration * float64(time.Millisecond))), nil
+}
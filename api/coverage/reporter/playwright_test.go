@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParsePlaywright(t *testing.T) {
+	results := parseFixture(t, PlaywrightFormat, readFixture(t, "playwright.json"))
+
+	compareResults(t, results, []resultSummary{
+		// Tests outside of a describe block are grouped under the file name
+		{Suite: "login.spec.ts", File: "login.spec.ts", Component: "chromium", Total: 1, Passes: 1, Duration: 100},
+		{Area: "Account", Feature: "Login", Suite: "Sign in", File: "login.spec.ts", Component: "chromium", Total: 2, Passes: 1,
+			Failures: 1, Duration: 600},
+		// The duration of a flaky test is the one of its last attempt
+		{Area: "Account", Feature: "Login", Suite: "Sign in", File: "login.spec.ts", Component: "firefox", Total: 2, Passes: 1,
+			Pending: 1, RetryPasses: 1, Duration: 300},
+	})
+
+	if want := time.Date(2026, 3, 1, 10, 0, 1, 500500000, time.UTC); !results[0].TestRun.Equal(want) {
+		t.Errorf("test run %s, want %s", results[0].TestRun, want)
+	}
+	if want := []string{StatePassed, StateFailed}; !slices.Equal(caseStates(results[1]), want) {
+		t.Errorf("states %v, want %v", caseStates(results[1]), want)
+	}
+	failed := results[1].Cases[1]
+	if failed.FullTitle != "Account|Login|Sign in with wrong password shows an error" ||
+		failed.ErrorMessage != "Error: expect(locator).toBeVisible() failed" || failed.Stack == "" {
+		t.Errorf("failed test case %+v", failed)
+	}
+	if want := []string{"smoke"}; !slices.Equal(results[1].Tags, want) {
+		t.Errorf("tags %v, want %v", results[1].Tags, want)
+	}
+}
+
+func TestPlaywrightUuid(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+	}{
+		{"with start time", "playwright.json"},
+		// The current time is the test run, it must not change the id
+		{"without start time", "playwright-no-start-time.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := readFixture(t, tt.fixture)
+			first := parseFixture(t, PlaywrightFormat, body)
+			time.Sleep(time.Millisecond)
+			second := parseFixture(t, PlaywrightFormat, body)
+
+			uuids := map[string]bool{}
+			for i := range first {
+				if first[i].Uuid != second[i].Uuid {
+					t.Errorf("uuid of result %d changed from %s to %s", i, first[i].Uuid, second[i].Uuid)
+				}
+				uuids[first[i].Uuid] = true
+			}
+			if len(uuids) != len(first) {
+				t.Errorf("%d different uuids for %d results", len(uuids), len(first))
+			}
+
+			changed := parseFixture(t, PlaywrightFormat, bytes.Replace(body, []byte(`"duration": 100`), []byte(`"duration": 101`), 1))
+			if changed[0].Uuid == first[0].Uuid {
+				t.Error("uuid did not change with the content of the report")
+			}
+		})
+	}
+}
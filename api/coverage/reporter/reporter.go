@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"encoding/json"
+)
+
+// Reporter reads the report of a test framework and maps it to test results
+type Reporter interface {
+	// Name of the report format, e.g. mocha. It can be sent in the format header of the upload.
+	Name() string
+	// Detect returns true if the report looks like a report of this format
+	Detect(body []byte) bool
+	// Parse maps the report to test results
+	Parse(body []byte) ([]TestResult, error)
+}
+
+// All known reporters in the order they were registered. Reporters register themselves in an init
+// function, so the slice is not modified after the start of the application.
+var reporters []Reporter
+
+// Register adds a reporter to the registry
+func Register(r Reporter) {
+	reporters = append(reporters, r)
+}
+
+// Get returns the reporter with the specified name
+func Get(name string) (Reporter, bool) {
+	for _, r := range reporters {
+		if r.Name() == name {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Detect returns the first reporter which recognizes the report
+func Detect(body []byte) (Reporter, bool) {
+	for _, r := range reporters {
+		if r.Detect(body) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Names returns the names of all registered reporters
+func Names() []string {
+	names := make([]string, 0, len(reporters))
+	for _, r := range reporters {
+		names = append(names, r.Name())
+	}
+	return names
+}
+
+// JSONObjectKeys returns the top level keys of a JSON object, or nil if the body is not a JSON object
+func JSONObjectKeys(body []byte) map[string]json.RawMessage {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil
+	}
+	return keys
+}
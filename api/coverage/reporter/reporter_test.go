@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Reads a report of the testdata directory
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// Parses the report with the reporter of the format
+func parseFixture(t *testing.T, format string, body []byte) []TestResult {
+	t.Helper()
+	r, ok := Get(format)
+	if !ok {
+		t.Fatalf("reporter %s is not registered", format)
+	}
+	results, err := r.Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+// The names and counts of a test result which are compared by the tests
+type resultSummary struct {
+	Area, Feature, Suite, File, Component                  string
+	Total, Passes, Pending, Failures, Skipped, RetryPasses int
+	Duration                                               int64
+}
+
+func summarize(results []TestResult) []resultSummary {
+	var summaries []resultSummary
+	for _, tr := range results {
+		summaries = append(summaries, resultSummary{Area: tr.Area, Feature: tr.Feature, Suite: tr.Suite, File: tr.File,
+			Component: tr.Component, Total: tr.Total, Passes: tr.Passes, Pending: tr.Pending, Failures: tr.Failures,
+			Skipped: tr.Skipped, RetryPasses: tr.RetryPasses, Duration: tr.Duration})
+	}
+	return summaries
+}
+
+func compareResults(t *testing.T, got []TestResult, want []resultSummary) {
+	t.Helper()
+	if s := summarize(got); !slices.Equal(s, want) {
+		t.Errorf("got results\n%+v\nwant\n%+v", s, want)
+	}
+}
+
+// Returns the states of the test cases of the result
+func caseStates(tr TestResult) []string {
+	var states []string
+	for _, tc := range tr.Cases {
+		states = append(states, tc.State)
+	}
+	return states
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+		want string
+	}{
+		{"mocha", []byte(`{"stats": {"tests": 0}, "results": []}`), MochaFormat},
+		{"junit testsuites", readFixture(t, "junit.xml"), JUnitFormat},
+		{"junit testsuite", readFixture(t, "junit-testsuite.xml"), JUnitFormat},
+		{"playwright", readFixture(t, "playwright.json"), PlaywrightFormat},
+		{"cucumber", readFixture(t, "cucumber.json"), CucumberFormat},
+		{"other JSON", []byte(`{"results": []}`), ""},
+		{"other XML", []byte(`<report><testsuite/></report>`), ""},
+		{"empty JSON array", []byte(`[]`), ""},
+		{"no report", []byte(`hello`), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if r, ok := Detect(tt.body); ok {
+				got = r.Name()
+			}
+			if got != tt.want {
+				t.Errorf("detected %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	for _, name := range []string{MochaFormat, JUnitFormat, PlaywrightFormat, CucumberFormat} {
+		if r, ok := Get(name); !ok || r.Name() != name {
+			t.Errorf("reporter %s is not registered", name)
+		}
+	}
+	if _, ok := Get("unknown"); ok {
+		t.Error("unknown reporter is registered")
+	}
+}
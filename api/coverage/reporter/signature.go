@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// Number of stack frames which are part of a failure signature. Frames further down are mostly the test framework.
+const signatureFrames = 5
+
+// Parts of a failure message which differ between the runs of the same failure, the most specific ones first
+var volatileParts = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<id>"},
+	{regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`), "<id>"},
+	// Hashes and generated ids contain letters and digits, words without digits are kept
+	{regexp.MustCompile(`(?i)\b[0-9a-f]*\d[0-9a-f]*[a-f][0-9a-f]*\b|\b[0-9a-f]*[a-f][0-9a-f]*\d[0-9a-f]*\b`), "<id>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "<n>"},
+	{regexp.MustCompile(`\s+`), " "},
+}
+
+// Removes the numbers, ids and timestamps of a failure message, so the same failure has the same message in every run
+func NormalizeFailureMessage(message string) string {
+	for _, p := range volatileParts {
+		message = p.pattern.ReplaceAllString(message, p.replacement)
+	}
+	return strings.TrimSpace(message)
+}
+
+// Returns the signature of a failure, a hash of its normalized message and the top frames of its stack outside of the
+// test files. The frames of a spec differ between the specs hitting the same failure, so they are not part of it.
+// Failures with the same signature very likely have the same root cause. Without message and stack it is empty.
+func FailureSignature(message string, stack string) string {
+	if message == "" && stack == "" {
+		return ""
+	}
+	parts := []string{NormalizeFailureMessage(firstLine(message))}
+	frames := 0
+	for _, line := range strings.Split(stack, "\n") {
+		frame, file, ok := stackFrame(strings.TrimSpace(line))
+		if !ok || testFile.MatchString(file) {
+			continue
+		}
+		parts = append(parts, NormalizeFailureMessage(lineNumbers.ReplaceAllString(frame, "")))
+		if frames++; frames == signatureFrames {
+			break
+		}
+	}
+	h := sha1.Sum([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(h[:])
+}
+
+var (
+	// JavaScript and Java: "at fn (file:line:column)" or "at file:line:column"
+	jsFrame = regexp.MustCompile(`^at (?:.*\((.+)\)|(.+))$`)
+	// Python: File "file", line 12, in fn
+	pythonFrame = regexp.MustCompile(`^File "([^"]+)", line \d+`)
+	// Go: /path/file.go:12 +0x1d or file_test.go:12: message
+	goFrame = regexp.MustCompile(`^(\S+\.go):\d+`)
+	// Line and column numbers of a frame, they change with every edit of the file
+	lineNumbers = regexp.MustCompile(`:\d+(:\d+)?\b|, line \d+`)
+	// Specs and the other files of the tests, e.g. page objects and support files
+	testFile = regexp.MustCompile(`(?i)(^|[/\\])(tests?|__tests__|specs?|e2e|cypress|playwright)[/\\]|` +
+		`\.(spec|test|cy)\.[a-z]+$|_test\.(go|py)$|(^|[/\\])test_[^/\\]*\.py$|(?-i:[a-z](Tests?|IT))\.(java|kt)$`)
+)
+
+// Returns the frame of a stack line and the file it points into, ok is false if the line is not a stack frame
+func stackFrame(line string) (frame string, file string, ok bool) {
+	if m := jsFrame.FindStringSubmatch(line); m != nil {
+		file = m[1] + m[2]
+		// Java: at com.example.LoginTest.login(LoginTest.java:12)
+		if strings.Contains(file, "(") {
+			file = file[strings.LastIndex(file, "(")+1:]
+		}
+		return line, lineNumbers.ReplaceAllString(file, ""), true
+	}
+	if m := pythonFrame.FindStringSubmatch(line); m != nil {
+		return line, m[1], true
+	}
+	if m := goFrame.FindStringSubmatch(line); m != nil {
+		// Only the file and line, a failure message or the offset may follow
+		return m[0], m[1], true
+	}
+	return "", "", false
+}
+
+// Multi-line messages contain e.g. the diff of an assertion, only the first line describes the failure
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
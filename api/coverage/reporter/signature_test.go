@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reporter
+
+import "testing"
+
+func TestNormalizeFailureMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"expected 402, got 500", "expected <n>, got <n>"},
+		{"Timeout 30000ms exceeded", "Timeout <n>ms exceeded"},
+		{"order 3f2a9c1e-4b7d-4e0a-9c1f-2b3c4d5e6f70 not found", "order <id> not found"},
+		{"created at 2026-01-02T10:11:12.345Z", "created at <time>"},
+		{"pointer 0x1d is nil", "pointer <id> is nil"},
+		{"session a1b2c3d4 expired", "session <id> expired"},
+		// Words without digits are kept
+		{"element #cafe is not visible", "element #cafe is not visible"},
+		{"  expected\ttrue\n to be  false ", "expected true to be false"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeFailureMessage(tt.message); got != tt.want {
+			t.Errorf("NormalizeFailureMessage(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestFailureSignature(t *testing.T) {
+	type failure struct {
+		message string
+		stack   string
+	}
+	tests := []struct {
+		name string
+		a, b failure
+		same bool
+	}{
+		{"javascript, other specs and lines",
+			failure{"TimeoutError: Timeout 30000ms exceeded", "at Checkout.pay (src/pages/checkout.ts:12:5)\nat tests/checkout.spec.ts:20:3"},
+			failure{"TimeoutError: Timeout 5000ms exceeded", "at Checkout.pay (src/pages/checkout.ts:14:5)\nat tests/cart.spec.ts:8:3"},
+			true},
+		{"javascript, other frame",
+			failure{"TimeoutError: Timeout 30000ms exceeded", "at Checkout.pay (src/pages/checkout.ts:12:5)"},
+			failure{"TimeoutError: Timeout 30000ms exceeded", "at Login.submit (src/pages/login.ts:12:5)"},
+			false},
+		{"python, other tests and lines",
+			failure{"AssertionError: expected 402", "Traceback (most recent call last):\n" +
+				"  File \"tests/test_payment.py\", line 12, in test_declines\n    pay(card)\n" +
+				"  File \"app/payment.py\", line 40, in pay\n    raise AssertionError()"},
+			failure{"AssertionError: expected 403", "Traceback (most recent call last):\n" +
+				"  File \"tests/test_refund.py\", line 7, in test_refund\n    pay(card)\n" +
+				"  File \"app/payment.py\", line 42, in pay\n    raise AssertionError()"},
+			true},
+		{"python, other file",
+			failure{"AssertionError: expected 402", "  File \"app/payment.py\", line 40, in pay"},
+			failure{"AssertionError: expected 402", "  File \"app/refund.py\", line 40, in refund"},
+			false},
+		{"go, other tests, lines and offsets",
+			failure{"unexpected status 500", "payment_test.go:20: unexpected status 500\n" +
+				"/src/app/payment.go:40 +0x1d\n/src/app/server.go:12 +0x2f"},
+			failure{"unexpected status 502", "refund_test.go:8: unexpected status 502\n" +
+				"/src/app/payment.go:41 +0x3a\n/src/app/server.go:12 +0x4b"},
+			true},
+		{"go, other file",
+			failure{"unexpected status 500", "/src/app/payment.go:40 +0x1d"},
+			failure{"unexpected status 500", "/src/app/refund.go:40 +0x1d"},
+			false},
+		{"java, other test classes",
+			failure{"NullPointerException", "at com.example.Payment.pay(Payment.java:40)\n" +
+				"at com.example.PaymentTest.declines(PaymentTest.java:12)"},
+			failure{"NullPointerException", "at com.example.Payment.pay(Payment.java:44)\n" +
+				"at com.example.RefundIT.refund(RefundIT.java:7)"},
+			true},
+		{"other message",
+			failure{"expected 402", "at Checkout.pay (src/pages/checkout.ts:12:5)"},
+			failure{"element not found", "at Checkout.pay (src/pages/checkout.ts:12:5)"},
+			false},
+		{"only the first line of the message",
+			failure{"expected 402\n- 402\n+ 500", ""},
+			failure{"expected 402\n- 402\n+ 503", ""},
+			true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := FailureSignature(tt.a.message, tt.a.stack), FailureSignature(tt.b.message, tt.b.stack)
+			if len(a) != 40 || len(b) != 40 {
+				t.Fatalf("signatures %q and %q are no SHA-1 hashes", a, b)
+			}
+			if (a == b) != tt.same {
+				t.Errorf("same signature %t, want %t", a == b, tt.same)
+			}
+		})
+	}
+	if got := FailureSignature("", ""); got != "" {
+		t.Errorf("signature without message and stack %q, want empty", got)
+	}
+}
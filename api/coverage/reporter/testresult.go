@@ -8,7 +8,12 @@ LICENSE file in the root directory of this source tree.
 
 package reporter
 
-import "time"
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"time"
+)
 
 type TestResult struct {
 	Area     string
@@ -20,6 +25,84 @@ type TestResult struct {
 	Pending  int
 	Failures int
 	Skipped  int
+	// Tests which passed after one or more failed attempts, they are part of the passes as well
+	RetryPasses int
+	// Sum of the durations of the test cases in ms
+	Duration int64
 	Uuid     string
 	TestRun  time.Time
+	// Set by reporters which know the component themselves, e.g. the Playwright project
+	Component string
+	// Tags of the suite, e.g. Cucumber tags or @tags in a Mocha suite title
+	Tags  []string
+	Cases []TestCase
+}
+
+// States of a single test case
+const (
+	StatePassed  = "passed"
+	StateFailed  = "failed"
+	StatePending = "pending"
+	StateSkipped = "skipped"
+	// The test failed and passed when it was retried
+	StatePassedAfterRetry = "passed-after-retry"
+)
+
+// TestCase is a single test of a test result. The duration is in ms.
+type TestCase struct {
+	Title        string
+	FullTitle    string
+	State        string
+	Duration     int64
+	ErrorMessage string
+	Stack        string
+	// Diff of the expected and actual value of a failed assertion
+	Diff string
+}
+
+// Adds the test case to the result and counts it according to its state
+func (tr *TestResult) addCase(tc TestCase) {
+	tr.Cases = append(tr.Cases, tc)
+	tr.Duration += tc.Duration
+	tr.Total++
+	switch tc.State {
+	case StatePassed:
+		tr.Passes++
+	case StateFailed:
+		tr.Failures++
+	case StateSkipped:
+		tr.Skipped++
+	case StatePending:
+		tr.Pending++
+	case StatePassedAfterRetry:
+		tr.Passes++
+		tr.RetryPasses++
+	}
+}
+
+// Splits a suite title using the {area}|{feature}|{suite} convention. If the title does not follow
+// the convention, only the suite is returned.
+func splitSuiteTitle(title string) (area, feature, suite string) {
+	if parts := strings.Split(title, "|"); len(parts) > 2 {
+		return parts[0], parts[1], parts[2]
+	}
+	return "", "", title
+}
+
+// Returns all words of a title starting with @, e.g. @smoke for "Login @smoke"
+func titleTags(title string) []string {
+	var tags []string
+	for _, word := range strings.Fields(title) {
+		if len(word) > 1 && strings.HasPrefix(word, "@") {
+			tags = append(tags, word)
+		}
+	}
+	return tags
+}
+
+// Some report formats do not provide an id for a result. To be able to detect duplicate uploads,
+// a stable id is derived from the given values.
+func deriveUuid(values ...string) string {
+	h := sha1.Sum([]byte(strings.Join(values, "\x1f")))
+	return hex.EncodeToString(h[:])
 }
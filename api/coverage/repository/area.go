@@ -83,6 +83,11 @@ func (cs CoverageStore) GetAllProductAreas(pid string) ([]model.Area, error) {
 	return areas, nil
 }
 
+// Returns the ID of the product of the area
+func (cs CoverageStore) GetAreaProductId(aid string) (int64, error) {
+	return cs.queryId("SELECT product_id FROM areas WHERE id = ?;", aid)
+}
+
 // Returns the area and feature id for the given area and feature name and the product id.
 func (cs CoverageStore) GetAreaAndFeatureId(area string, feature string, productId string) (int64, int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -105,6 +110,28 @@ func (cs CoverageStore) GetAreaAndFeatureId(area string, feature string, product
 	return aid, fid, err
 }
 
+// GetAreaAndFeatureIdByFeatureName returns the IDs of the area and feature for a feature name of the product, which is
+// used for test results without area, e.g. a Gherkin feature without tags. Returns sql.ErrNoRows if there is no
+// feature with the name or if more than one area has a feature with the name.
+func (cs CoverageStore) GetAreaAndFeatureIdByFeatureName(feature string, productId string) (int64, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query, args, err := sq.Select("MIN(a.id)", "MIN(f.id)").
+		From("areas a").
+		Join("features f ON a.id = f.area_id").
+		Where("a.product_id = ?", productId).
+		Where("f.name = ?", feature).
+		Having("COUNT(*) = 1").
+		ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+	var aid, fid int64
+	err = cs.db.QueryRowContext(ctx, query, args...).Scan(&aid, &fid)
+	return aid, fid, err
+}
+
 // GetAreaIdByNameAndProductId returns the area ID for an area with the given name in the specified product.
 // Returns 0 and sql.ErrNoRows if no matching area is found.
 func (cs CoverageStore) GetAreaIdByNameAndProductId(areaName string, productId string) (int64, error) {
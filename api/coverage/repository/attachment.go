@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// The attachments are not deleted by the database together with their test results, as their content has to be deleted
+// as well. The deletes of test results delete them explicitly, the expiry job removes the ones left behind.
+const createAttachmentStmt = `CREATE TABLE IF NOT EXISTS attachments (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT NOT NULL,
+	test_id INT NOT NULL,
+	name VARCHAR(255),
+	content_type VARCHAR(255),
+	size BIGINT,
+	blob_key VARCHAR(255) NOT NULL,
+	created_by VARCHAR(255),
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	INDEX (test_id),
+	INDEX (created_at)
+	)`
+
+const insertAttachmentStmt = "INSERT INTO attachments (product_id, test_id, name, content_type, size, blob_key, created_by) VALUES (?,?,?,?,?,?,?)"
+
+const deleteAttachmentStmt = "DELETE FROM attachments WHERE id = ?"
+
+const selectAttachment = "SELECT a.id, a.product_id, a.test_id, COALESCE(a.name,''), COALESCE(a.content_type,''), COALESCE(a.size,0), a.blob_key, COALESCE(a.created_by,''), a.created_at FROM attachments a"
+
+func (cs CoverageStore) CreateAttachmentsTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createAttachmentStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Attachments DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+func (cs CoverageStore) InsertAttachment(a model.Attachment) (int64, error) {
+	return cs.executeSql(insertAttachmentStmt, a.ProductId, a.TestId, a.Name, a.ContentType, a.Size, a.BlobKey, a.CreatedBy)
+}
+
+func (cs CoverageStore) DeleteAttachment(id string) (int64, error) {
+	return cs.executeSql(deleteAttachmentStmt, id)
+}
+
+// Get the attachment with the specified ID, returns sql.ErrNoRows if it does not exist
+func (cs CoverageStore) GetAttachment(id string) (model.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a := model.Attachment{}
+	err := cs.db.QueryRowContext(ctx, selectAttachment+" WHERE a.id = ?;", id).
+		Scan(&a.Id, &a.ProductId, &a.TestId, &a.Name, &a.ContentType, &a.Size, &a.BlobKey, &a.CreatedBy, &a.CreatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Error %s when query context", err)
+	}
+	return a, err
+}
+
+// Get all attachments of the test result
+func (cs CoverageStore) GetTestAttachments(testId string) ([]model.Attachment, error) {
+	return cs.getAttachments(selectAttachment+" WHERE a.test_id = ? ORDER BY a.id;", testId)
+}
+
+// Get all attachments of the test results of the product
+func (cs CoverageStore) GetProductAttachments(productId string) ([]model.Attachment, error) {
+	return cs.getAttachments(selectAttachment+" WHERE a.product_id = ? ORDER BY a.id;", productId)
+}
+
+// Deletes the attachments of the test results t selected by the join and the condition. The deleted attachments are
+// returned, so their content can be deleted after the transaction.
+func (cs CoverageStore) deleteTestResultAttachments(join string, where string, params ...any) ([]model.Attachment, error) {
+	attachments, err := cs.getAttachments(selectAttachment+" JOIN tests t ON t.id = a.test_id "+join+" WHERE "+where+" ORDER BY a.id;", params...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cs.executeSql("DELETE a FROM attachments a JOIN tests t ON t.id = a.test_id "+join+" WHERE "+where, params...); err != nil {
+		return nil, fmt.Errorf("error deleting attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// Get the attachments uploaded before the specified time and the attachments of deleted test results
+func (cs CoverageStore) GetExpiredAttachments(before time.Time) ([]model.Attachment, error) {
+	return cs.getAttachments(selectAttachment+" LEFT JOIN tests t ON t.id = a.test_id WHERE a.created_at < ? OR t.id IS NULL ORDER BY a.id;", before)
+}
+
+func (cs CoverageStore) getAttachments(query string, params ...any) ([]model.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := cs.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var attachments = []model.Attachment{}
+	for rows.Next() {
+		a := model.Attachment{}
+		if err := rows.Scan(&a.Id, &a.ProductId, &a.TestId, &a.Name, &a.ContentType, &a.Size, &a.BlobKey, &a.CreatedBy,
+			&a.CreatedAt); err != nil {
+			log.Println(err)
+			return attachments, err
+		}
+		attachments = append(attachments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
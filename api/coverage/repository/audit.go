@@ -0,0 +1,89 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// The audit log of a product is kept after the product has been deleted
+const createAuditLogStmt = `CREATE TABLE IF NOT EXISTS audit_log (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT NOT NULL,
+	action VARCHAR(64) NOT NULL,
+	entity VARCHAR(64) NOT NULL,
+	entity_id INT,
+	actor VARCHAR(255),
+	details TEXT,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	INDEX (product_id, created_at)
+	)`
+
+const insertAuditEntryStmt = "INSERT INTO audit_log (product_id, action, entity, entity_id, actor, details) VALUES (?,?,?,?,?,?)"
+
+func (cs CoverageStore) CreateAuditLogTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createAuditLogStmt)
+	if err != nil {
+		log.Printf("Error %s when creating AuditLog DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+func (cs CoverageStore) InsertAuditEntry(e model.AuditEntry) (int64, error) {
+	return cs.executeSql(insertAuditEntryStmt, e.ProductId, e.Action, e.Entity, e.EntityId, e.Actor, e.Details)
+}
+
+// Get the latest entries of the audit log of the product, the latest first. Without limit all entries are returned.
+func (cs CoverageStore) GetAuditLog(productId string, limit uint64) ([]model.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("id", "product_id", "action", "entity", "COALESCE(entity_id,0)", "COALESCE(actor,'')", "COALESCE(details,'')", "created_at").
+		From("audit_log").
+		Where("product_id = ?", productId).
+		OrderBy("created_at DESC", "id DESC")
+	if limit > 0 {
+		builder = builder.Limit(limit)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var entries = []model.AuditEntry{}
+	for rows.Next() {
+		e := model.AuditEntry{}
+		if err := rows.Scan(&e.Id, &e.ProductId, &e.Action, &e.Entity, &e.EntityId, &e.Actor, &e.Details, &e.CreatedAt); err != nil {
+			log.Println(err)
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
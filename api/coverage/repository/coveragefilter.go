@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Number of days of the coverage window, if the product does not configure it
+const DefaultCoverageDays = 28
+
+// CoverageFilter restricts the coverage queries to the test results of a time window, a branch and an environment.
+// Empty values do not filter.
+type CoverageFilter struct {
+	From        time.Time
+	To          time.Time
+	Branch      string
+	Environment string
+	// Includes test results without branch, e.g. results uploaded before the branch was recorded
+	IncludeEmptyBranch bool
+}
+
+// Returns the conditions for the test run, branch and environment columns of the table with the specified alias
+func (f CoverageFilter) where(alias string) sq.And {
+	return append(f.dimensions(alias), f.window(alias+".testrun")...)
+}
+
+// Returns the conditions for the branch and environment columns of the table with the specified alias
+func (f CoverageFilter) dimensions(alias string) sq.And {
+	conditions := sq.And{}
+	if f.Branch != "" {
+		if f.IncludeEmptyBranch {
+			conditions = append(conditions, sq.Or{sq.Eq{alias + ".branch": f.Branch}, sq.Eq{alias + ".branch": ""}, sq.Eq{alias + ".branch": nil}})
+		} else {
+			conditions = append(conditions, sq.Eq{alias + ".branch": f.Branch})
+		}
+	}
+	if f.Environment != "" {
+		conditions = append(conditions, sq.Eq{alias + ".environment": f.Environment})
+	}
+	return conditions
+}
+
+// Returns the conditions for the time window on the specified column. The end of the window is exclusive.
+func (f CoverageFilter) window(column string) sq.And {
+	conditions := sq.And{}
+	if !f.From.IsZero() {
+		conditions = append(conditions, sq.GtOrEq{column: f.From})
+	}
+	if !f.To.IsZero() {
+		conditions = append(conditions, sq.Lt{column: f.To})
+	}
+	return conditions
+}
+
+// Returns the coverage window of the last days
+func DefaultCoverageFilter(days int64) CoverageFilter {
+	return CoverageFilter{From: time.Now().AddDate(0, 0, -int(days))}
+}
+
+// Returns the filter for the coverage window and the default branch of the product
+func (cs CoverageStore) ProductCoverageFilter(productId string) (CoverageFilter, error) {
+	return cs.productCoverageFilter("SELECT COALESCE(default_branch, ''), COALESCE(coverage_days, 0) FROM products WHERE id = ?;", productId)
+}
+
+// Returns the filter for the coverage window and the default branch of the product of the area
+func (cs CoverageStore) AreaCoverageFilter(areaId string) (CoverageFilter, error) {
+	return cs.productCoverageFilter(`SELECT COALESCE(p.default_branch, ''), COALESCE(p.coverage_days, 0) FROM areas a
+		JOIN products p ON p.id = a.product_id WHERE a.id = ?;`, areaId)
+}
+
+// Returns the filter for the coverage window and the default branch of the product of the feature
+func (cs CoverageStore) FeatureCoverageFilter(featureId string) (CoverageFilter, error) {
+	return cs.productCoverageFilter(`SELECT COALESCE(p.default_branch, ''), COALESCE(p.coverage_days, 0) FROM features f
+		JOIN areas a ON a.id = f.area_id JOIN products p ON p.id = a.product_id WHERE f.id = ?;`, featureId)
+}
+
+func (cs CoverageStore) productCoverageFilter(query string, id string) (CoverageFilter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var branch string
+	var days int64
+	if err := cs.db.QueryRowContext(ctx, query, id).Scan(&branch, &days); err != nil {
+		return DefaultCoverageFilter(DefaultCoverageDays), err
+	}
+	if days <= 0 {
+		days = DefaultCoverageDays
+	}
+	f := DefaultCoverageFilter(days)
+	f.Branch = branch
+	f.IncludeEmptyBranch = true
+	return f, nil
+}
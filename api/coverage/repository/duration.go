@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// Increase of the median duration of a suite in percent which is reported as regression, if the product does not configure it
+const DefaultDurationRegressionPct = 20
+
+// SuiteDurations are the durations of the results of a suite, the oldest first
+type SuiteDurations struct {
+	Component string
+	Suite     string
+	FileName  string
+	Runs      []model.DurationPoint
+}
+
+// Get the durations of the results of all suites of the product in the window, optionally only of one component.
+// Results without duration are not returned, e.g. the ones uploaded by reporters without durations.
+func (cs CoverageStore) GetSuiteDurations(productId string, component string, filter CoverageFilter) ([]SuiteDurations, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("t.component", testSuiteColumn+" AS suite", testFileColumn+" AS file", "t.id", "t.testrun", "t.duration",
+		"t.passes", "t.failures", "COALESCE(t.retry_passes,0)").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where("t.product_id = ?", productId).
+		Where("t.duration > 0").
+		Where(filter.where("t")).
+		OrderBy("t.component", "suite", "file", "t.testrun", "t.id")
+	if component != "" {
+		builder = builder.Where("t.component = ?", component)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var suites []SuiteDurations
+	for rows.Next() {
+		var s SuiteDurations
+		var p model.DurationPoint
+		var passes, failures, retryPasses int64
+		if err := rows.Scan(&s.Component, &s.Suite, &s.FileName, &p.TestId, &p.TestRun, &p.Duration, &passes, &failures,
+			&retryPasses); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		p.Status = testResultStatus(passes, failures, retryPasses)
+		// Rows are ordered by suite, so a new suite starts when the identity changes
+		if n := len(suites); n == 0 || suites[n-1].Component != s.Component || suites[n-1].Suite != s.Suite || suites[n-1].FileName != s.FileName {
+			suites = append(suites, s)
+		}
+		last := &suites[len(suites)-1]
+		last.Runs = append(last.Runs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return suites, nil
+}
+
+// Get the durations of a test case of the suite in the window, the oldest first. The test case is identified by its full title,
+// or by its title if the reporter does not provide a full title.
+func (cs CoverageStore) GetTestCaseDurations(productId string, component string, suite string, file string, title string, filter CoverageFilter) ([]model.DurationPoint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	suite, file, err := cs.ResolveTestAlias(productId, component, suite, file)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := sq.Select("t.id", "t.testrun", "COALESCE(tc.duration,0)", "tc.state").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Join("test_cases tc ON tc.test_id = t.id").
+		Where("t.product_id = ?", productId).
+		Where("t.component = ?", component).
+		Where(testSuiteColumn+" = ?", suite).
+		Where(testFileColumn+" = ?", file).
+		Where("COALESCE(NULLIF(tc.full_title, ''), tc.title) = ?", title).
+		Where(filter.where("t")).
+		OrderBy("t.testrun", "t.id", "tc.id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var points = []model.DurationPoint{}
+	for rows.Next() {
+		p := model.DurationPoint{}
+		if err := rows.Scan(&p.TestId, &p.TestRun, &p.Duration, &p.Status); err != nil {
+			log.Println(err)
+			return points, err
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
@@ -13,6 +13,7 @@ import (
 	"log"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/TestAndWin/e2e-coverage/coverage/model"
 )
 
@@ -59,11 +60,19 @@ func (cs CoverageStore) DeleteExplTestsByAreaId(areaId string) (int64, error) {
 }
 
 // Get all exploratory tests for the specified area
-func (cs CoverageStore) GetExplTests(aid string) ([]model.ExplTest, error) {
+func (cs CoverageStore) GetExplTests(aid string, filter CoverageFilter) ([]model.ExplTest, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	rows, err := cs.db.QueryContext(ctx, "SELECT id, area_id, summary, rating, testrun, tester FROM expl_tests WHERE area_id = ? AND testrun > ?;", aid, time.Now().AddDate(0, 0, -28))
+	query, args, err := sq.Select("id", "area_id", "summary", "rating", "testrun", "tester").
+		From("expl_tests").
+		Where("area_id = ?", aid).
+		Where(filter.window("testrun")).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := cs.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("Error %s when query context", err)
 		return nil, err
@@ -85,12 +94,45 @@ func (cs CoverageStore) GetExplTests(aid string) ([]model.ExplTest, error) {
 	return et, nil
 }
 
-// Returns the number of expl. tests and the average rating for the specified area id
-func (cs CoverageStore) GetExplTestOverviewForArea(aid int64) (model.Area, error) {
+// Returns the number of expl. tests and the average rating for all areas of the specified product. The key of the map
+// is the area id, areas without expl. tests in the window are missing.
+func (cs CoverageStore) GetExplTestOverviewForProduct(pid string, filter CoverageFilter) (map[int64]model.Area, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	var a = model.Area{}
-	err := cs.db.QueryRowContext(ctx, "SELECT count(*), COALESCE(AVG(rating),0) FROM expl_tests WHERE area_id = ? AND testrun > ?;", aid, time.Now().AddDate(0, 0, -28)).Scan(&a.ExplTests, &a.ExplRating)
-	return a, err
+	query, args, err := sq.Select("e.area_id", "count(*)", "COALESCE(AVG(e.rating),0)").
+		From("expl_tests e").
+		Join("areas a ON a.id = e.area_id").
+		Where("a.product_id = ?", pid).
+		Where(filter.window("e.testrun")).
+		GroupBy("e.area_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	overview := make(map[int64]model.Area)
+	for rows.Next() {
+		a := model.Area{}
+		if err := rows.Scan(&a.Id, &a.ExplTests, &a.ExplRating); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		overview[a.Id] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return overview, nil
+}
+
+// Returns the ID of the product of the expl. test
+func (cs CoverageStore) GetExplTestProductId(id string) (int64, error) {
+	return cs.queryId("SELECT a.product_id FROM expl_tests e JOIN areas a ON a.id = e.area_id WHERE e.id = ?;", id)
 }
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+)
+
+// Get the failed test cases of the latest result of every test of the product in the coverage window, grouped by their
+// failure signature. The clusters with the most failures are returned first. Failures without message and stack are
+// not part of a cluster. Without limit all clusters are returned.
+func (cs CoverageStore) GetFailureClusters(productId string, filter CoverageFilter, limit int) ([]model.FailureCluster, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	partition := "PARTITION BY t.component, " + testSuiteColumn + ", " + testFileColumn
+	results := sq.Select("t.id", "t.component", testSuiteColumn+" AS suite", testFileColumn+" AS file", "t.testrun", "t.url",
+		"ROW_NUMBER() OVER ("+partition+" ORDER BY t.testrun DESC, t.id DESC) AS latest").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where("t.product_id = ?", productId).
+		Where(filter.where("t"))
+	// The latest failures first, so the first failure of a cluster is its latest one
+	query, args, err := sq.Select("r.id", "tc.id", "r.component", "r.suite", "r.file", "COALESCE(tc.title,'')", "r.testrun",
+		"COALESCE(r.url,'')", "COALESCE(tc.error_message,'')", "COALESCE(tc.stack,'')", "tc.signature").
+		FromSelect(results, "r").
+		Join("test_cases tc ON tc.test_id = r.id").
+		Where("r.latest = 1").
+		Where(sq.Eq{"tc.state": reporter.StateFailed}).
+		OrderBy("r.testrun DESC", "r.id DESC", "tc.id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	clusters := map[string]*model.FailureCluster{}
+	suites := map[string]map[string]bool{}
+	for rows.Next() {
+		var f model.FailedTest
+		var message, stack string
+		var stored sql.NullString
+		if err := rows.Scan(&f.TestId, &f.TestCaseId, &f.Component, &f.Suite, &f.FileName, &f.Title, &f.TestRun, &f.Url,
+			&message, &stack, &stored); err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		// Test cases stored before the signature was stored have none
+		signature := stored.String
+		if !stored.Valid {
+			signature = reporter.FailureSignature(message, stack)
+		}
+		if signature == "" {
+			continue
+		}
+
+		c, ok := clusters[signature]
+		if !ok {
+			c = &model.FailureCluster{Signature: signature, Message: message, NormalizedMessage: reporter.NormalizeFailureMessage(message),
+				Stack: stack, FirstSeen: f.TestRun, LastSeen: f.TestRun, Tests: []model.FailedTest{}}
+			clusters[signature] = c
+			suites[signature] = map[string]bool{}
+		}
+		c.Failures++
+		c.FirstSeen = f.TestRun
+		c.Tests = append(c.Tests, f)
+		suites[signature][f.Component+"\x1f"+f.Suite+"\x1f"+f.FileName] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	list := []model.FailureCluster{}
+	for signature, c := range clusters {
+		c.Suites = int64(len(suites[signature]))
+		list = append(list, *c)
+	}
+	slices.SortFunc(list, func(a, b model.FailureCluster) int {
+		if a.Failures != b.Failures {
+			return int(b.Failures - a.Failures)
+		}
+		if c := b.LastSeen.Compare(a.LastSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Signature, b.Signature)
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
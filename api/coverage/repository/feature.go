@@ -145,3 +145,8 @@ func (cs CoverageStore) GetFeatureIdByNameAndAreaId(featureName string, areaId i
 
 	return featureId, nil
 }
+
+// Returns the ID of the product of the feature
+func (cs CoverageStore) GetFeatureProductId(fid string) (int64, error) {
+	return cs.queryId("SELECT a.product_id FROM features f JOIN areas a ON a.id = f.area_id WHERE f.id = ?;", fid)
+}
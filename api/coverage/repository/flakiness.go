@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// Number of the latest results of a test used to detect flaky tests, if the product does not configure it
+const DefaultFlakyWindow = 10
+
+// Maximum number of results used to detect flaky tests
+const MaxFlakyWindow = 100
+
+// Number of flips within the window which marks a test as flaky. A flip between results of the same commit always does.
+const flakyFlips = 2
+
+// A test is identified by its product, component, suite and file. For a renamed test the current name is stored.
+const createTestFlakinessStmt = `CREATE TABLE IF NOT EXISTS test_flakiness (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT NOT NULL,
+	component VARCHAR(255) NOT NULL DEFAULT '',
+	suite VARCHAR(255) NOT NULL,
+	file VARCHAR(255) NOT NULL,
+	runs INT,
+	flips INT,
+	same_commit_flips INT,
+	score DOUBLE,
+	flaky BOOLEAN DEFAULT FALSE,
+	last_flip DATETIME,
+	retried_runs INT,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY (product_id, component, suite, file),
+	FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	)`
+
+const upsertTestFlakinessStmt = `INSERT INTO test_flakiness (product_id, component, suite, file, runs, flips, same_commit_flips, score, flaky, last_flip, retried_runs)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?)
+	ON DUPLICATE KEY UPDATE runs = VALUES(runs), flips = VALUES(flips), same_commit_flips = VALUES(same_commit_flips),
+	score = VALUES(score), flaky = VALUES(flaky), last_flip = VALUES(last_flip), retried_runs = VALUES(retried_runs)`
+
+func (cs CoverageStore) CreateTestFlakinessTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createTestFlakinessStmt)
+	if err != nil {
+		log.Printf("Error %s when creating TestFlakiness DB table\n", err)
+		return err
+	}
+	return cs.addColumnIfNotExists("test_flakiness", "retried_runs", "INT")
+}
+
+// Computes the flakiness of the test from its latest results on the default branch of the product and stores it.
+func (cs CoverageStore) UpdateTestFlakiness(productId string, component string, suite string, file string, settings model.ProductSettings) error {
+	suite, file, err := cs.ResolveTestAlias(productId, component, suite, file)
+	if err != nil {
+		return err
+	}
+	f, err := cs.computeTestFlakiness(productId, component, suite, file, settings)
+	if err != nil {
+		return err
+	}
+	_, err = cs.executeSql(upsertTestFlakinessStmt, settings.ProductId, component, suite, file, f.Runs, f.Flips,
+		f.SameCommitFlips, f.Score, f.Flaky, f.LastFlip, f.RetriedRuns)
+	return err
+}
+
+func (cs CoverageStore) computeTestFlakiness(productId string, component string, suite string, file string, settings model.ProductSettings) (model.TestFlakiness, error) {
+	window := settings.FlakyWindow
+	if window <= 0 {
+		window = DefaultFlakyWindow
+	}
+	results, err := cs.GetLatestTestResults(productId, component, suite, file,
+		CoverageFilter{Branch: settings.DefaultBranch, IncludeEmptyBranch: true}, uint64(window))
+	if err != nil {
+		return model.TestFlakiness{}, fmt.Errorf("error getting latest results of test %s %s: %w", suite, file, err)
+	}
+	return testFlakiness(results), nil
+}
+
+// Computes the flakiness of the test results, which must be ordered by their test run, the oldest first.
+// Skipped results are not considered. A result which passed after a retry counts as passed for the flips, but as
+// instability for the score.
+func testFlakiness(results []model.TestHistoryEntry) model.TestFlakiness {
+	f := model.TestFlakiness{History: []model.TestFlip{}}
+	var previous *model.TestHistoryEntry
+	for i, r := range results {
+		if r.Status == model.TestSkipped {
+			continue
+		}
+		f.Runs++
+		if r.Status == model.TestPassedAfterRetry {
+			f.RetriedRuns++
+		}
+		if previous != nil && flipStatus(previous.Status) != flipStatus(r.Status) {
+			flip := model.TestFlip{TestId: r.TestId, TestRun: r.TestRun, From: previous.Status, To: r.Status, CommitSha: r.CommitSha,
+				SameCommit: r.CommitSha != "" && r.CommitSha == previous.CommitSha}
+			f.Flips++
+			if flip.SameCommit {
+				f.SameCommitFlips++
+			}
+			f.LastFlip = &results[i].TestRun
+			f.History = append(f.History, flip)
+		}
+		previous = &results[i]
+	}
+	if f.Runs > 0 {
+		f.Score = float64(f.Flips+f.SameCommitFlips+f.RetriedRuns) / float64(2*(f.Runs-1)+f.Runs)
+	}
+	f.Flaky = f.SameCommitFlips > 0 || f.Flips >= flakyFlips || f.RetriedRuns > 0
+	return f
+}
+
+// A result which passed after a retry has no failures, so it does not flip from a passed result
+func flipStatus(status string) string {
+	if status == model.TestPassedAfterRetry {
+		return model.TestPassed
+	}
+	return status
+}
+
+// Get the flaky tests of the product with the highest flakiness score, together with the flips of their latest results.
+func (cs CoverageStore) GetFlakyTests(productId string, settings model.ProductSettings, limit uint64) ([]model.TestFlakiness, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("id", "product_id", "component", "suite", "file", "runs", "flips", "same_commit_flips", "score", "flaky", "last_flip",
+		"COALESCE(retried_runs,0)").
+		From("test_flakiness").
+		Where("product_id = ?", productId).
+		Where("flaky").
+		OrderBy("score DESC", "last_flip DESC", "component", "suite", "file")
+	if limit > 0 {
+		builder = builder.Limit(limit)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var tests = []model.TestFlakiness{}
+	for rows.Next() {
+		f := model.TestFlakiness{}
+		if err := rows.Scan(&f.Id, &f.ProductId, &f.Component, &f.Suite, &f.FileName, &f.Runs, &f.Flips, &f.SameCommitFlips,
+			&f.Score, &f.Flaky, &f.LastFlip, &f.RetriedRuns); err != nil {
+			log.Println(err)
+			return tests, err
+		}
+		tests = append(tests, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, t := range tests {
+		f, err := cs.computeTestFlakiness(productId, t.Component, t.Suite, t.FileName, settings)
+		if err != nil {
+			return nil, err
+		}
+		tests[i].History = f.History
+	}
+	return tests, nil
+}
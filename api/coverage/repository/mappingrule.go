@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+const createMappingRuleStmt = `CREATE TABLE IF NOT EXISTS mapping_rules (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT,
+	priority INT,
+	rule_type VARCHAR(20),
+	pattern VARCHAR(500),
+	area VARCHAR(255),
+	feature VARCHAR(255),
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	FOREIGN KEY (product_id) REFERENCES products(id)
+	)`
+
+const insertMappingRuleStmt = "INSERT INTO mapping_rules (product_id, priority, rule_type, pattern, area, feature) VALUES (?,?,?,?,?,?)"
+
+const updateMappingRuleStmt = "UPDATE mapping_rules SET priority = ?, rule_type = ?, pattern = ?, area = ?, feature = ? WHERE id = ?"
+
+const deleteMappingRuleStmt = "DELETE FROM mapping_rules WHERE id = ?"
+
+func (cs CoverageStore) CreateMappingRulesTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createMappingRuleStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Mapping Rules DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+func (cs CoverageStore) InsertMappingRule(r model.MappingRule) (int64, error) {
+	return cs.executeSql(insertMappingRuleStmt, r.ProductId, r.Priority, r.Type, r.Pattern, r.Area, r.Feature)
+}
+
+func (cs CoverageStore) UpdateMappingRule(r model.MappingRule) (int64, error) {
+	return cs.executeSql(updateMappingRuleStmt, r.Priority, r.Type, r.Pattern, r.Area, r.Feature, r.Id)
+}
+
+func (cs CoverageStore) DeleteMappingRule(id string) (int64, error) {
+	return cs.executeSql(deleteMappingRuleStmt, id)
+}
+
+// Get all mapping rules of the specified product, ordered by their priority
+func (cs CoverageStore) GetMappingRules(pid string) ([]model.MappingRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := cs.db.QueryContext(ctx, "SELECT id, product_id, priority, rule_type, pattern, area, feature FROM mapping_rules WHERE product_id = ? ORDER BY priority, id;", pid)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var rules = []model.MappingRule{}
+	for rows.Next() {
+		r := model.MappingRule{}
+		if err := rows.Scan(&r.Id, &r.ProductId, &r.Priority, &r.Type, &r.Pattern, &r.Area, &r.Feature); err != nil {
+			log.Println(err)
+			return rules, err
+		}
+		rules = append(rules, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
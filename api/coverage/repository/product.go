@@ -19,6 +19,11 @@ import (
 const createProductStmt = `CREATE TABLE IF NOT EXISTS products (
 	id INT AUTO_INCREMENT PRIMARY KEY,
 	name VARCHAR(255),
+	strict_mapping BOOLEAN DEFAULT FALSE,
+	default_branch VARCHAR(255),
+	coverage_days INT,
+	flaky_window INT,
+	duration_regression_pct INT,
 	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
 	)`
 
@@ -28,6 +33,22 @@ const updateProductStmt = "UPDATE products SET name = ? WHERE id = ?"
 
 const deleteProductStmt = "DELETE FROM products WHERE id = ?"
 
+// Rows which reference the product and are deleted together with it. The tables were created without ON DELETE CASCADE.
+// The referencing rows are deleted first: the attachments, then the test results with their test cases, which reference
+// the runs and the unmapped tests. The flakiness, quarantines and snapshots are deleted by the database.
+var deleteProductDependentsStmts = []string{
+	"DELETE FROM attachments WHERE product_id = ?",
+	"DELETE FROM tests WHERE product_id = ?",
+	"DELETE FROM runs WHERE product_id = ?",
+	"DELETE FROM mapping_rules WHERE product_id = ?",
+	"DELETE FROM test_assignments WHERE product_id = ?",
+	"DELETE FROM test_aliases WHERE product_id = ?",
+	"DELETE FROM unmapped_tests WHERE product_id = ?",
+	"DELETE FROM audit_log WHERE product_id = ?",
+}
+
+const updateProductSettingsStmt = "UPDATE products SET strict_mapping = ?, default_branch = ?, coverage_days = ?, flaky_window = ?, duration_regression_pct = ? WHERE id = ?"
+
 func (cs CoverageStore) CreateProductsTable() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -37,7 +58,19 @@ func (cs CoverageStore) CreateProductsTable() error {
 		log.Printf("Error %s when creating Products DB table\n", err)
 		return err
 	}
-	return nil
+	if err := cs.addColumnIfNotExists("products", "strict_mapping", "BOOLEAN DEFAULT FALSE"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("products", "default_branch", "VARCHAR(255)"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("products", "coverage_days", "INT"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("products", "flaky_window", "INT"); err != nil {
+		return err
+	}
+	return cs.addColumnIfNotExists("products", "duration_regression_pct", "INT")
 }
 
 func (cs CoverageStore) InsertProduct(p model.Product) (int64, error) {
@@ -48,8 +81,29 @@ func (cs CoverageStore) UpdateProduct(p model.Product) (int64, error) {
 	return cs.executeSql(updateProductStmt, p.Name, p.Id)
 }
 
-func (cs CoverageStore) DeleteProduct(id string) (int64, error) {
-	return cs.executeSql(deleteProductStmt, id)
+// Deletes the product together with its test results, attachments, runs, mapping rules, test assignments, aliases,
+// unmapped tests and audit log in one transaction. A product with areas cannot be deleted. The deleted attachments are
+// returned, as the content of the attachments is not part of the transaction and has to be deleted afterwards.
+func (cs CoverageStore) DeleteProduct(id string) (int64, []model.Attachment, error) {
+	var res int64
+	var attachments []model.Attachment
+	err := cs.WithTx(func(tx *CoverageStore) error {
+		var err error
+		if attachments, err = tx.GetProductAttachments(id); err != nil {
+			return err
+		}
+		for _, stmt := range deleteProductDependentsStmts {
+			if _, err := tx.executeSql(stmt, id); err != nil {
+				return err
+			}
+		}
+		res, err = tx.executeSql(deleteProductStmt, id)
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return res, attachments, nil
 }
 
 // Returns all products
@@ -77,3 +131,21 @@ func (cs CoverageStore) GetAllProducts() ([]model.Product, error) {
 	}
 	return products, nil
 }
+
+func (cs CoverageStore) UpdateProductSettings(ps model.ProductSettings) (int64, error) {
+	return cs.executeSql(updateProductSettingsStmt, ps.StrictMapping, ps.DefaultBranch, ps.CoverageDays, ps.FlakyWindow, ps.DurationRegressionPct,
+		ps.ProductId)
+}
+
+// Returns the settings of the specified product
+func (cs CoverageStore) GetProductSettings(pid string) (model.ProductSettings, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ps model.ProductSettings
+	err := cs.db.QueryRowContext(ctx, `SELECT id, COALESCE(strict_mapping, FALSE), COALESCE(default_branch, ''), COALESCE(coverage_days, ?),
+		COALESCE(flaky_window, ?), COALESCE(duration_regression_pct, ?) FROM products WHERE id = ?;`,
+		DefaultCoverageDays, DefaultFlakyWindow, DefaultDurationRegressionPct, pid).
+		Scan(&ps.ProductId, &ps.StrictMapping, &ps.DefaultBranch, &ps.CoverageDays, &ps.FlakyWindow, &ps.DurationRegressionPct)
+	return ps, err
+}
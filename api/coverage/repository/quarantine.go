@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// A test is identified by its product, component, suite and file. For a renamed test the current name is stored.
+const createTestQuarantineStmt = `CREATE TABLE IF NOT EXISTS test_quarantines (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT NOT NULL,
+	component VARCHAR(255) NOT NULL DEFAULT '',
+	suite VARCHAR(255) NOT NULL,
+	file VARCHAR(255) NOT NULL,
+	reason TEXT,
+	owner VARCHAR(255),
+	expires_at DATETIME NOT NULL,
+	created_by VARCHAR(255),
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE KEY (product_id, component, suite, file),
+	FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	)`
+
+// Quarantining a quarantined test again replaces its reason, owner and expiry
+const upsertTestQuarantineStmt = `INSERT INTO test_quarantines (product_id, component, suite, file, reason, owner, expires_at, created_by)
+	VALUES (?,?,?,?,?,?,?,?)
+	ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), reason = VALUES(reason), owner = VALUES(owner),
+	expires_at = VALUES(expires_at), created_by = VALUES(created_by), created_at = CURRENT_TIMESTAMP`
+
+const deleteTestQuarantineStmt = "DELETE FROM test_quarantines WHERE id = ?"
+
+// Joins the quarantine of a test, if it has not expired. The test run is the parameter of the join.
+const testQuarantineJoin = "test_quarantines q ON q.product_id = t.product_id AND q.component = t.component AND q.suite = " +
+	testSuiteColumn + " AND q.file = " + testFileColumn + " AND q.expires_at > ?"
+
+const selectTestQuarantine = "SELECT id, product_id, component, suite, file, COALESCE(reason,''), COALESCE(owner,''), expires_at, COALESCE(created_by,''), created_at FROM test_quarantines"
+
+func (cs CoverageStore) CreateTestQuarantinesTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createTestQuarantineStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Test Quarantines DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+// Stores the quarantine of the test, a renamed test is quarantined with its current name.
+func (cs CoverageStore) InsertTestQuarantine(q model.TestQuarantine) (model.TestQuarantine, error) {
+	suite, file, err := cs.ResolveTestAlias(fmt.Sprint(q.ProductId), q.Component, q.Suite, q.FileName)
+	if err != nil {
+		return q, err
+	}
+	q.Suite, q.FileName = suite, file
+	q.Id, err = cs.executeSql(upsertTestQuarantineStmt, q.ProductId, q.Component, q.Suite, q.FileName, q.Reason, q.Owner,
+		q.ExpiresAt, q.CreatedBy)
+	return q, err
+}
+
+func (cs CoverageStore) DeleteTestQuarantine(id string) (int64, error) {
+	return cs.executeSql(deleteTestQuarantineStmt, id)
+}
+
+// Get the quarantine with the specified ID, returns sql.ErrNoRows if it does not exist
+func (cs CoverageStore) GetTestQuarantine(id string) (model.TestQuarantine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	q := model.TestQuarantine{}
+	err := cs.db.QueryRowContext(ctx, selectTestQuarantine+" WHERE id = ?;", id).
+		Scan(&q.Id, &q.ProductId, &q.Component, &q.Suite, &q.FileName, &q.Reason, &q.Owner, &q.ExpiresAt, &q.CreatedBy, &q.CreatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Error %s when query context", err)
+	}
+	return q, err
+}
+
+// Get the quarantines of the product which have not expired, the ones expiring first first
+func (cs CoverageStore) GetTestQuarantines(productId string) ([]model.TestQuarantine, error) {
+	return cs.getTestQuarantines(selectTestQuarantine+" WHERE product_id = ? AND expires_at > ? ORDER BY expires_at, id;", productId, time.Now())
+}
+
+// Get the expired quarantines of all products
+func (cs CoverageStore) GetExpiredTestQuarantines() ([]model.TestQuarantine, error) {
+	return cs.getTestQuarantines(selectTestQuarantine+" WHERE expires_at <= ? ORDER BY expires_at, id;", time.Now())
+}
+
+func (cs CoverageStore) getTestQuarantines(query string, params ...any) ([]model.TestQuarantine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := cs.db.QueryContext(ctx, query, params...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var quarantines = []model.TestQuarantine{}
+	for rows.Next() {
+		q := model.TestQuarantine{}
+		if err := rows.Scan(&q.Id, &q.ProductId, &q.Component, &q.Suite, &q.FileName, &q.Reason, &q.Owner, &q.ExpiresAt,
+			&q.CreatedBy, &q.CreatedAt); err != nil {
+			log.Println(err)
+			return quarantines, err
+		}
+		quarantines = append(quarantines, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return quarantines, nil
+}
@@ -21,7 +21,15 @@ import (
 
 // CoverageStore handles all database operations for coverage data
 type CoverageStore struct {
-	db *sql.DB
+	db dbtx
+}
+
+// Operations of a DB connection, which are also part of a transaction
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 // Interface for CoverageStore to enable mocking in tests
@@ -32,6 +40,17 @@ type CoverageRepository interface {
 	CreateExplTestsTable() error
 	CreateFeaturesTable() error
 	CreateTestsTable() error
+	CreateTestCasesTable() error
+	CreateMappingRulesTable() error
+	CreateUnmappedTestsTable() error
+	CreateTestAssignmentsTable() error
+	CreateTestAliasesTable() error
+	CreateRunsTable() error
+	CreateCoverageSnapshotsTable() error
+	CreateTestFlakinessTable() error
+	CreateTestQuarantinesTable() error
+	CreateAuditLogTable() error
+	CreateAttachmentsTable() error
 	CreateAllTables() error
 }
 
@@ -55,6 +74,30 @@ func WithDB(database *sql.DB) *CoverageStore {
 	}
 }
 
+// WithTx calls fn with a store whose operations are part of one transaction. The transaction is committed if fn
+// succeeds and rolled back if it returns an error. A store which is already part of a transaction is used as is.
+func (cs CoverageStore) WithTx(fn func(tx *CoverageStore) error) error {
+	database, ok := cs.db.(*sql.DB)
+	if !ok {
+		return fn(&cs)
+	}
+	// The statements have their own timeout, the transaction lasts as long as fn
+	tx, err := database.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	if err := fn(&CoverageStore{db: tx}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Printf("Error %s when rolling back transaction", rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
 // CreateAllTables creates all required tables for the application
 func (store *CoverageStore) CreateAllTables() error {
 	tables := []struct {
@@ -65,7 +108,18 @@ func (store *CoverageStore) CreateAllTables() error {
 		{"Areas", store.CreateAreasTable},
 		{"ExplTests", store.CreateExplTestsTable},
 		{"Features", store.CreateFeaturesTable},
+		{"UnmappedTests", store.CreateUnmappedTestsTable},
+		{"Runs", store.CreateRunsTable},
 		{"Tests", store.CreateTestsTable},
+		{"TestCases", store.CreateTestCasesTable},
+		{"MappingRules", store.CreateMappingRulesTable},
+		{"TestAssignments", store.CreateTestAssignmentsTable},
+		{"TestAliases", store.CreateTestAliasesTable},
+		{"CoverageSnapshots", store.CreateCoverageSnapshotsTable},
+		{"TestFlakiness", store.CreateTestFlakinessTable},
+		{"TestQuarantines", store.CreateTestQuarantinesTable},
+		{"AuditLog", store.CreateAuditLogTable},
+		{"Attachments", store.CreateAttachmentsTable},
 	}
 
 	for _, table := range tables {
@@ -77,6 +131,39 @@ func (store *CoverageStore) CreateAllTables() error {
 	return nil
 }
 
+// Adds a column to a table created by an older version. CREATE TABLE IF NOT EXISTS does not change existing tables.
+func (cs CoverageStore) addColumnIfNotExists(table, column, definition string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var count int
+	err := cs.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?",
+		table, column).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("error checking column %s.%s: %w", table, column, err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	log.Printf("Adding column %s to table %s", column, table)
+	if _, err := cs.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("error adding column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+// Returns the ID selected by the specified query, sql.ErrNoRows if there is no row
+func (cs CoverageStore) queryId(query string, params ...any) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var id int64
+	err := cs.db.QueryRowContext(ctx, query, params...).Scan(&id)
+	return id, err
+}
+
 // Inserts/Deletes a row using the specified statement and params
 func (cs CoverageStore) executeSql(statement string, params ...any) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
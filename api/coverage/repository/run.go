@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+const createRunStmt = `CREATE TABLE IF NOT EXISTS runs (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT,
+	commit_sha VARCHAR(64),
+	branch VARCHAR(255),
+	build_number VARCHAR(255),
+	job_url VARCHAR(500),
+	environment VARCHAR(255),
+	triggered_by VARCHAR(255),
+	started_at DATETIME,
+	ended_at DATETIME,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	FOREIGN KEY (product_id) REFERENCES products(id)
+	)`
+
+const insertRunStmt = "INSERT INTO runs (product_id, commit_sha, branch, build_number, job_url, environment, triggered_by, started_at, ended_at) VALUES (?,?,?,?,?,?,?,?,?)"
+
+// Another upload of the same run extends the time of the run
+const extendRunStmt = "UPDATE runs SET started_at = LEAST(started_at, ?), ended_at = GREATEST(ended_at, ?) WHERE id = ?"
+
+const deleteRunStmt = "DELETE FROM runs WHERE id = ?"
+
+func (cs CoverageStore) CreateRunsTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createRunStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Runs DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+func (cs CoverageStore) InsertRun(r model.Run) (int64, error) {
+	return cs.executeSql(insertRunStmt, r.ProductId, r.CommitSha, r.Branch, r.BuildNumber, r.JobUrl, r.Environment, r.TriggeredBy, r.StartedAt, r.EndedAt)
+}
+
+func (cs CoverageStore) ExtendRun(id int64, startedAt time.Time, endedAt time.Time) (int64, error) {
+	return cs.executeSql(extendRunStmt, startedAt, endedAt, id)
+}
+
+func (cs CoverageStore) DeleteRun(id int64) (int64, error) {
+	return cs.executeSql(deleteRunStmt, id)
+}
+
+// Returns the run with the sum of its test results
+func (cs CoverageStore) GetRun(id string) (model.Run, error) {
+	runs, err := cs.getRuns(sq.Eq{"r.id": id}, 1)
+	if err != nil {
+		return model.Run{}, err
+	}
+	if len(runs) == 0 {
+		return model.Run{}, sql.ErrNoRows
+	}
+	return runs[0], nil
+}
+
+// Get the latest runs of the specified product
+func (cs CoverageStore) GetProductRuns(productId string, filter CoverageFilter, limit uint64) ([]model.Run, error) {
+	return cs.getRuns(sq.And{sq.Eq{"r.product_id": productId}, filter.dimensions("r"), filter.window("r.ended_at")}, limit)
+}
+
+func (cs CoverageStore) getRuns(where sq.Sqlizer, limit uint64) ([]model.Run, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("r.id", "r.product_id", "r.commit_sha", "r.branch", "r.build_number", "r.job_url", "r.environment",
+		"r.triggered_by", "r.started_at", "r.ended_at", "COUNT(t.id)", "COALESCE(SUM(t.total),0)", "COALESCE(SUM(t.passes),0)",
+		"COALESCE(SUM(t.pending),0)", "COALESCE(SUM(t.failures),0)", "COALESCE(SUM(t.skipped),0)", "COALESCE(SUM(t.retry_passes),0)").
+		From("runs r").
+		LeftJoin("tests t ON t.run_id = r.id").
+		Where(where).
+		GroupBy("r.id").
+		OrderBy("r.ended_at DESC", "r.id DESC").
+		Limit(limit)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var runs = []model.Run{}
+	for rows.Next() {
+		r := model.Run{}
+		if err := rows.Scan(&r.Id, &r.ProductId, &r.CommitSha, &r.Branch, &r.BuildNumber, &r.JobUrl, &r.Environment, &r.TriggeredBy,
+			&r.StartedAt, &r.EndedAt, &r.Tests, &r.Total, &r.Passes, &r.Pending, &r.Failures, &r.Skipped, &r.RetryPasses); err != nil {
+			log.Println(err)
+			return runs, err
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// Get all test results of the specified run
+func (cs CoverageStore) GetRunTests(runId string) ([]model.Test, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("t.id", "t.product_id", "COALESCE(t.area_id,0)", "COALESCE(t.feature_id,0)", testSuiteColumn, testFileColumn,
+		"t.component", "t.url", "t.total", "t.passes", "t.pending", "t.failures", "t.skipped", "t.uuid", "t.is_first", "t.testrun",
+		"COALESCE(t.retry_passes,0)").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where("t.run_id = ?", runId).
+		OrderBy("t.component", testSuiteColumn, testFileColumn)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var tests = []model.Test{}
+	for rows.Next() {
+		t := model.Test{}
+		if err := scanTest(rows, &t); err != nil {
+			log.Println(err)
+			return tests, err
+		}
+		tests = append(tests, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
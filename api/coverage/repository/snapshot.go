@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// Area and feature ID are 0 and not NULL for the rollups, otherwise the unique key would not prevent duplicates
+const createCoverageSnapshotStmt = `CREATE TABLE IF NOT EXISTS coverage_snapshots (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT NOT NULL,
+	area_id INT NOT NULL DEFAULT 0,
+	feature_id INT NOT NULL DEFAULT 0,
+	snapshot_date DATE NOT NULL,
+	total INT,
+	first_total INT,
+	passes INT,
+	pending INT,
+	failures INT,
+	skipped INT,
+	retry_passes INT NOT NULL DEFAULT 0,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY (product_id, area_id, feature_id, snapshot_date),
+	FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	)`
+
+// A snapshot of the same day replaces the previous one
+const upsertCoverageSnapshotStmt = `INSERT INTO coverage_snapshots (product_id, area_id, feature_id, snapshot_date, total, first_total, passes, pending, failures, skipped, retry_passes)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?)
+	ON DUPLICATE KEY UPDATE total = VALUES(total), first_total = VALUES(first_total), passes = VALUES(passes),
+	pending = VALUES(pending), failures = VALUES(failures), skipped = VALUES(skipped), retry_passes = VALUES(retry_passes)`
+
+func (cs CoverageStore) CreateCoverageSnapshotsTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createCoverageSnapshotStmt)
+	if err != nil {
+		log.Printf("Error %s when creating CoverageSnapshots DB table\n", err)
+		return err
+	}
+	if err := cs.addColumnIfNotExists("coverage_snapshots", "retry_passes", "INT NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cs CoverageStore) upsertCoverageSnapshot(s model.CoverageSnapshot) (int64, error) {
+	return cs.executeSql(upsertCoverageSnapshotStmt, s.ProductId, s.AreaId, s.FeatureId, s.Date, s.Total, s.FirstTotal,
+		s.Passes, s.Pending, s.Failures, s.Skipped, s.RetryPasses)
+}
+
+// Stores the coverage of the product, its areas and features at the end of the specified day (UTC). The coverage window
+// and the default branch of the product are used, so a snapshot shows what the coverage pages showed at that time.
+// Areas and features without tests in the window get a snapshot with 0 tests.
+func (cs CoverageStore) TakeCoverageSnapshot(productId string, day time.Time) error {
+	settings, err := cs.GetProductSettings(productId)
+	if err != nil {
+		return fmt.Errorf("error getting settings of product %s: %w", productId, err)
+	}
+	days := settings.CoverageDays
+	if days <= 0 {
+		days = DefaultCoverageDays
+	}
+	day = day.UTC()
+	end := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	filter := CoverageFilter{From: end.AddDate(0, 0, -int(days)), To: end, Branch: settings.DefaultBranch, IncludeEmptyBranch: true}
+	date := day.Format(time.DateOnly)
+
+	areas, err := cs.GetAllProductAreas(productId)
+	if err != nil {
+		return fmt.Errorf("error getting areas of product %s: %w", productId, err)
+	}
+	areaCoverage, err := cs.GetAreaCoverageForProduct(productId, filter)
+	if err != nil {
+		return fmt.Errorf("error getting area coverage of product %s: %w", productId, err)
+	}
+
+	product := model.CoverageSnapshot{ProductId: settings.ProductId, Date: date}
+	for _, a := range areas {
+		area := snapshotOf(areaCoverage[a.Id])
+		area.ProductId, area.AreaId, area.Date = settings.ProductId, a.Id, date
+		if _, err := cs.upsertCoverageSnapshot(area); err != nil {
+			return fmt.Errorf("error storing snapshot of area %d: %w", a.Id, err)
+		}
+		addSnapshot(&product, area)
+
+		aid := strconv.FormatInt(a.Id, 10)
+		features, err := cs.GetAllAreaFeatures(aid)
+		if err != nil {
+			return fmt.Errorf("error getting features of area %d: %w", a.Id, err)
+		}
+		featureCoverage, err := cs.GetFeatureCoverageForArea(aid, filter)
+		if err != nil {
+			return fmt.Errorf("error getting feature coverage of area %d: %w", a.Id, err)
+		}
+		for _, f := range features {
+			feature := snapshotOf(featureCoverage[f.Id])
+			feature.ProductId, feature.AreaId, feature.FeatureId, feature.Date = settings.ProductId, a.Id, f.Id, date
+			if _, err := cs.upsertCoverageSnapshot(feature); err != nil {
+				return fmt.Errorf("error storing snapshot of feature %d: %w", f.Id, err)
+			}
+		}
+	}
+
+	if _, err := cs.upsertCoverageSnapshot(product); err != nil {
+		return fmt.Errorf("error storing snapshot of product %s: %w", productId, err)
+	}
+	return nil
+}
+
+func snapshotOf(t model.Test) model.CoverageSnapshot {
+	return model.CoverageSnapshot{Total: t.Total, FirstTotal: t.FirstTotal, Passes: t.Passes, Pending: t.Pending,
+		Failures: t.Failures, Skipped: t.Skipped, RetryPasses: t.RetryPasses}
+}
+
+func addSnapshot(sum *model.CoverageSnapshot, s model.CoverageSnapshot) {
+	sum.Total += s.Total
+	sum.FirstTotal += s.FirstTotal
+	sum.Passes += s.Passes
+	sum.Pending += s.Pending
+	sum.Failures += s.Failures
+	sum.Skipped += s.Skipped
+	sum.RetryPasses += s.RetryPasses
+}
+
+// Get the daily snapshots of the product, area or feature in the time window, ordered by date.
+// With a feature ID the snapshots of the feature are returned, with an area ID the ones of the area, otherwise the ones of the product.
+func (cs CoverageStore) GetCoverageTrend(productId string, areaId int64, featureId int64, filter CoverageFilter) ([]model.CoverageSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("s.id", "s.product_id", "s.area_id", "s.feature_id", "DATE_FORMAT(s.snapshot_date, '%Y-%m-%d')",
+		"s.total", "s.first_total", "s.passes", "s.pending", "s.failures", "s.skipped", "s.retry_passes").
+		From("coverage_snapshots s").
+		Where(snapshotOwner(productId, areaId, featureId)).
+		Where(filter.window("s.snapshot_date")).
+		OrderBy("s.snapshot_date")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var snapshots = []model.CoverageSnapshot{}
+	for rows.Next() {
+		s := model.CoverageSnapshot{}
+		if err := rows.Scan(&s.Id, &s.ProductId, &s.AreaId, &s.FeatureId, &s.Date, &s.Total, &s.FirstTotal, &s.Passes,
+			&s.Pending, &s.Failures, &s.Skipped, &s.RetryPasses); err != nil {
+			log.Println(err)
+			return snapshots, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func snapshotOwner(productId string, areaId int64, featureId int64) sq.Eq {
+	if featureId > 0 {
+		return sq.Eq{"s.product_id": productId, "s.feature_id": featureId}
+	}
+	return sq.Eq{"s.product_id": productId, "s.area_id": areaId, "s.feature_id": 0}
+}
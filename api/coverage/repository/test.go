@@ -37,18 +37,36 @@ const CREATE_TEST = `CREATE TABLE IF NOT EXISTS tests (
 	testrun datetime,
 	uuid VARCHAR(255),
 	is_first BOOLEAN,
+	unmapped_id int,
+	run_id int,
+	branch VARCHAR(255),
+	environment VARCHAR(255),
+	duration BIGINT,
+	retry_passes int,
 	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
        FOREIGN KEY (feature_id) REFERENCES features(id),
+       FOREIGN KEY (run_id) REFERENCES runs(id),
        FOREIGN KEY (area_id) REFERENCES areas(id)
        )`
 
-const insertTestStmt = "INSERT INTO tests (product_id, area_id, feature_id, suite, file, component, url, total, passes, pending, failures, skipped, uuid, is_first, testrun) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+const insertTestStmt = "INSERT INTO tests (product_id, area_id, feature_id, suite, file, component, url, total, passes, pending, failures, skipped, uuid, is_first, testrun, run_id, branch, environment, duration, retry_passes) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 
-const insertTestNoAreaFeatureStmt = "INSERT INTO tests (product_id, suite, file, component, url, total, passes, pending, failures, skipped, uuid, is_first, testrun) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)"
+const insertTestNoAreaFeatureStmt = "INSERT INTO tests (product_id, suite, file, component, url, total, passes, pending, failures, skipped, uuid, is_first, testrun, unmapped_id, run_id, branch, environment, duration, retry_passes) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 
-const deleteTestStmt = "DELETE FROM tests WHERE component = ? AND suite = ? AND file = ?"
+// The duration of a test result is the sum of the durations of its test cases
+const backfillTestDurationsStmt = `UPDATE tests t SET t.duration = (SELECT COALESCE(SUM(tc.duration), 0) FROM test_cases tc WHERE tc.test_id = t.id)
+	WHERE t.duration IS NULL`
 
-const testQueryPeriodDays = 28
+const deleteTestStmt = "DELETE t FROM tests t LEFT JOIN " + testAliasJoin + " WHERE t.component = ? AND " + testSuiteColumn + " = ? AND " + testFileColumn + " = ?"
+
+// is_first is only set for the first upload of a test with an area and feature. When tests stored without area and
+// feature are assigned later, is_first has to be calculated again for all tests of the feature.
+const updateFirstUploadsStmt = `UPDATE tests t
+	JOIN (SELECT component, suite, file, MIN(testrun) AS first_run FROM tests
+		WHERE area_id = ? AND feature_id = ? GROUP BY component, suite, file) f
+	ON f.component = t.component AND f.suite = t.suite AND f.file = t.file
+	SET t.is_first = (t.testrun = f.first_run)
+	WHERE t.area_id = ? AND t.feature_id = ?`
 
 func (cs CoverageStore) CreateTestsTable() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -59,19 +77,74 @@ func (cs CoverageStore) CreateTestsTable() error {
 		log.Printf("Error %s when creating Tests DB table\n", err)
 		return err
 	}
+	if err := cs.addColumnIfNotExists("tests", "unmapped_id", "int"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("tests", "run_id", "int"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("tests", "branch", "VARCHAR(255)"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("tests", "environment", "VARCHAR(255)"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("tests", "duration", "BIGINT"); err != nil {
+		return err
+	}
+	if err := cs.addColumnIfNotExists("tests", "retry_passes", "int"); err != nil {
+		return err
+	}
+	// Test results uploaded before the duration was stored, it is retried at the next start if it fails
+	if _, err := cs.executeSql(backfillTestDurationsStmt); err != nil {
+		log.Printf("Error %s when setting the duration of test results", err)
+	}
 	return nil
 }
 
-func (cs CoverageStore) InsertTestResult(productId string, areaId int64, featureId int64, component string, url string, isFirst bool, tr reporter.TestResult) (int64, error) {
-	return cs.executeSql(insertTestStmt, productId, areaId, featureId, tr.Suite, tr.File, component, url, tr.Total, tr.Passes, tr.Pending, tr.Failures, tr.Skipped, tr.Uuid, isFirst, tr.TestRun)
+func (cs CoverageStore) InsertTestResult(productId string, areaId int64, featureId int64, component string, url string, isFirst bool, run model.Run, tr reporter.TestResult) (int64, error) {
+	return cs.executeSql(insertTestStmt, productId, areaId, featureId, tr.Suite, tr.File, component, url, tr.Total, tr.Passes, tr.Pending, tr.Failures, tr.Skipped, tr.Uuid, isFirst, tr.TestRun,
+		run.Id, run.Branch, run.Environment, tr.Duration, tr.RetryPasses)
+}
+
+// Inserts a test result which could not be mapped to an area and feature. If the names are waiting in the
+// triage queue, the test is linked to the unmapped test, so it can be assigned once the names are accepted.
+func (cs CoverageStore) InsertTestResultWithoutAreaFeature(productId string, component string, url string, isFirst bool, unmappedId int64, run model.Run, tr reporter.TestResult) (int64, error) {
+	return cs.executeSql(insertTestNoAreaFeatureStmt, productId, tr.Suite, tr.File, component, url, tr.Total, tr.Passes, tr.Pending, tr.Failures, tr.Skipped, tr.Uuid, isFirst, tr.TestRun,
+		sql.NullInt64{Int64: unmappedId, Valid: unmappedId != 0}, run.Id, run.Branch, run.Environment, tr.Duration, tr.RetryPasses)
 }
 
-func (cs CoverageStore) InsertTestResultWithoutAreaFeature(productId string, component string, url string, isFirst bool, tr reporter.TestResult) (int64, error) {
-	return cs.executeSql(insertTestNoAreaFeatureStmt, productId, tr.Suite, tr.File, component, url, tr.Total, tr.Passes, tr.Pending, tr.Failures, tr.Skipped, tr.Uuid, isFirst, tr.TestRun)
+func (cs CoverageStore) UpdateFirstUploads(areaId int64, featureId int64) error {
+	if _, err := cs.executeSql(updateFirstUploadsStmt, areaId, featureId, areaId, featureId); err != nil {
+		return fmt.Errorf("error updating first uploads: %w", err)
+	}
+	return nil
 }
 
-func (cs CoverageStore) DeleteTest(component string, suite string, file string) (int64, error) {
-	return cs.executeSql(deleteTestStmt, component, suite, file)
+// Deletes the test results of the test together with their attachments, the deleted attachments are returned
+func (cs CoverageStore) DeleteTest(component string, suite string, file string) (int64, []model.Attachment, error) {
+	return cs.deleteTests(func(tx *CoverageStore) ([]model.Attachment, error) {
+		return tx.deleteTestResultAttachments("LEFT JOIN "+testAliasJoin, "t.component = ? AND "+testSuiteColumn+" = ? AND "+testFileColumn+" = ?",
+			component, suite, file)
+	}, deleteTestStmt, component, suite, file)
+}
+
+// Deletes the attachments and then the test results in one transaction
+func (cs CoverageStore) deleteTests(deleteAttachments func(tx *CoverageStore) ([]model.Attachment, error), stmt string, params ...any) (int64, []model.Attachment, error) {
+	var res int64
+	var attachments []model.Attachment
+	err := cs.WithTx(func(tx *CoverageStore) error {
+		var err error
+		if attachments, err = deleteAttachments(tx); err != nil {
+			return err
+		}
+		res, err = tx.executeSql(stmt, params...)
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return res, attachments, nil
 }
 
 // HasTestBeenUploaded checks if a test with the given UUID has already been uploaded.
@@ -103,14 +176,20 @@ func (cs CoverageStore) IsThisTheFirstUpload(pid string, aid int64, fid int64, s
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Results of a renamed suite or file belong to the same test
+	suite, file, err := cs.ResolveTestAlias(pid, component, suite, file)
+	if err != nil {
+		return false, err
+	}
+
 	query := `
-			SELECT 1 FROM tests 
-			WHERE product_id = ? AND area_id = ? AND feature_id = ? AND suite = ? AND file = ? AND component = ? 
+			SELECT 1 FROM tests t LEFT JOIN ` + testAliasJoin + `
+			WHERE t.product_id = ? AND t.area_id = ? AND t.feature_id = ? AND ` + testSuiteColumn + ` = ? AND ` + testFileColumn + ` = ? AND t.component = ? 
 			LIMIT 1
 	`
 
 	var exists bool
-	err := cs.db.QueryRowContext(ctx, query, pid, aid, fid, suite, file, component).Scan(&exists)
+	err = cs.db.QueryRowContext(ctx, query, pid, aid, fid, suite, file, component).Scan(&exists)
 
 	if err == sql.ErrNoRows {
 		return true, nil
@@ -124,21 +203,28 @@ func (cs CoverageStore) IsThisTheFirstUpload(pid string, aid int64, fid int64, s
 }
 
 // Get all tests for the specified feature id
-func (cs CoverageStore) GetAllFeatureTests(fid string) ([]model.Test, error) {
-	return cs.GetTests(fid, "SELECT id, product_id, area_id, feature_id, suite, file, component, url, total, passes, pending, failures, skipped, uuid, is_first, testrun FROM tests WHERE feature_id = ? AND testrun > ? ORDER BY component, suite, file, testrun DESC;")
-}
-
-// Get all tests for the specified product id
-func (cs CoverageStore) GetAllProductTests(pid string) ([]model.Test, error) {
-	return cs.GetTests(pid, "SELECT id, product_id, COALESCE(area_id,0) as area_id, COALESCE(feature_id,0) as feature_id, suite, file, component, url, total, passes, pending, failures, skipped, uuid, is_first, testrun FROM tests WHERE product_id = ? AND testrun > ? ORDER BY component, suite, file, testrun DESC;")
+func (cs CoverageStore) GetAllFeatureTests(fid string, filter CoverageFilter) ([]model.Test, error) {
+	return cs.GetTests(sq.Select("t.id", "t.product_id", "t.area_id", "t.feature_id", testSuiteColumn, testFileColumn, "t.component", "t.url",
+		"t.total", "t.passes", "t.pending", "t.failures", "t.skipped", "t.uuid", "t.is_first", "t.testrun", "COALESCE(t.retry_passes,0)").
+		Where("t.feature_id = ?", fid), filter)
 }
 
-// GetTests retrieves tests for a given ID within the last 28 days.
-func (cs CoverageStore) GetTests(id string, query string) ([]model.Test, error) {
+// GetTests retrieves the tests selected by the builder within the coverage window of the filter.
+func (cs CoverageStore) GetTests(builder sq.SelectBuilder, filter CoverageFilter) ([]model.Test, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	rows, err := cs.db.QueryContext(ctx, query, id, time.Now().AddDate(0, 0, -testQueryPeriodDays))
+	query, args, err := builder.
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where(filter.where("t")).
+		OrderBy("t.component", testSuiteColumn, testFileColumn, "t.testrun DESC", "t.id DESC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -158,7 +244,7 @@ func (cs CoverageStore) GetTests(id string, query string) ([]model.Test, error)
 			currentTest = initializeNewTest(currentTest)
 			tests = append(tests, currentTest)
 		} else {
-			updateExistingTest(&tests[len(tests)-1], prevTest, currentTest)
+			updateExistingTest(&tests[len(tests)-1], currentTest)
 		}
 
 		prevTest = &currentTest
@@ -173,7 +259,8 @@ func (cs CoverageStore) GetTests(id string, query string) ([]model.Test, error)
 
 func scanTest(rows *sql.Rows, t *model.Test) error {
 	return rows.Scan(&t.Id, &t.ProductId, &t.AreaId, &t.FeatureId, &t.Suite, &t.FileName, &t.Component,
-		&t.Url, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun)
+		&t.Url, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun,
+		&t.RetryPasses)
 }
 
 func shouldAddNewTest(prev *model.Test, current model.Test) bool {
@@ -181,110 +268,87 @@ func shouldAddNewTest(prev *model.Test, current model.Test) bool {
 		(prev.Component == current.Component && prev.FileName != current.FileName)
 }
 
+// FirstTotal is the number of tests at the start of the coverage window, so it is taken from the oldest result
+// of a test in the window. If the latest result is the first upload of the test, the test was added in the window and
+// its latest total is subtracted, so a test whose only result is its first upload counts 0. Whether the oldest result
+// is the first upload does not matter.
+func firstTotal(latest model.Test, oldest model.Test) int64 {
+	if latest.IsFirst {
+		return oldest.Total - latest.Total
+	}
+	return oldest.Total
+}
+
 func initializeNewTest(t model.Test) model.Test {
 	t.TotalTestRuns = 1
 	t.FailedTestRuns = 0
 	if t.Failures > 0 {
 		t.FailedTestRuns = 1
 	}
-	if !t.IsFirst {
-		t.FirstTotal = t.Total
+	t.RetriedTestRuns = 0
+	if t.RetryPasses > 0 {
+		t.RetriedTestRuns = 1
 	}
+	t.FirstTotal = firstTotal(t, t)
 	return t
 }
 
-func updateExistingTest(existing *model.Test, prev *model.Test, current model.Test) {
-	existing.FirstTotal = existing.FirstTotal - prev.Total + current.Total
+// The results of a test are ordered by their test run descending, so the existing test is the latest result and the
+// current one is older
+func updateExistingTest(existing *model.Test, current model.Test) {
+	existing.FirstTotal = firstTotal(*existing, current)
 	if current.Failures > 0 {
 		existing.FailedTestRuns++
 	}
+	if current.RetryPasses > 0 {
+		existing.RetriedTestRuns++
+	}
 	existing.TotalTestRuns++
 }
 
 // Get the test coverage information for all areas of the specified procduct
-func (cs CoverageStore) GetAreaCoverageForProduct(productId string) (map[int64]model.Test, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	builder := sq.Select("t.id", "t.product_id", "t.area_id", "t.feature_id", "t.suite", "t.file", "t.component", "t.url",
-		"t.total", "t.passes", "t.pending", "t.failures", "t.skipped", "t.uuid", "t.is_first", "t.testrun").
+func (cs CoverageStore) GetAreaCoverageForProduct(productId string, filter CoverageFilter) (map[int64]model.Test, error) {
+	return cs.getCoverage("area_id", sq.Select().
 		From("tests t").
 		Join("areas a ON a.id = t.area_id").
+		LeftJoin(testAliasJoin).
 		Where("a.product_id = ?", productId).
-		Where("t.testrun > ?", time.Now().AddDate(0, 0, -testQueryPeriodDays)).
-		OrderBy("t.area_id", "t.feature_id", "t.component", "t.suite", "t.file", "t.testrun DESC")
-	query, args, err := builder.ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := cs.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		log.Printf("Error %s when query context", err)
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer rows.Close()
-
-	// Map where the key is the area id
-	coverage := make(map[int64]model.Test)
-	var prevRow *model.Test
-
-	for rows.Next() {
-		t := model.Test{}
-		if err := rows.Scan(&t.Id, &t.ProductId, &t.AreaId, &t.FeatureId, &t.Suite, &t.FileName, &t.Component, &t.Url, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun); err != nil {
-			log.Println(err)
-			return nil, err
-		}
-		if r, ok := coverage[t.AreaId]; ok {
-			// We already know the area
-			if prevRow == nil || prevRow.FeatureId != t.FeatureId || prevRow.Suite != t.Suite || prevRow.Component != t.Component || (prevRow.Component == t.Component && prevRow.FileName != t.FileName) {
-				// Check if it is a different feature
-				// And only the latest test result is taken into account, if there is more than one for the suite and file
-				r.Total += t.Total
-				r.Passes += t.Passes
-				r.Pending += t.Pending
-				r.Failures += t.Failures
-				r.Skipped += t.Skipped
-				// When this test has not been executed in the last 28d for the first time, we use the total value
-				if !t.IsFirst {
-					r.FirstTotal += t.Total
-				}
-			} else {
-				// A bit tricky: FirstTotal should store the number of tests starting the period.
-				// But we sorted the SQL result desc, so we have to get the total value from the first test
-				// And not to run another SQL, we do it this way
-				// With this we first remove the number of previous test (same suite, file, ...) and then adding the current one.
-				r.FirstTotal = r.FirstTotal - prevRow.Total + t.Total
-			}
-			coverage[t.AreaId] = r
-		} else {
-			// new area
-			// When this test has not been executed in the last 28d for the first time, we start with the total number of tests
-			if !t.IsFirst {
-				t.FirstTotal = t.Total
-			}
-			coverage[t.AreaId] = t
-		}
-		prevRow = &t
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return coverage, nil
+		Where(filter.where("t")))
 }
 
 // Get the test coverage information for all features of the specified area
-func (cs CoverageStore) GetFeatureCoverageForArea(areaId string) (map[int64]model.Test, error) {
+func (cs CoverageStore) GetFeatureCoverageForArea(areaId string, filter CoverageFilter) (map[int64]model.Test, error) {
+	return cs.getCoverage("feature_id", sq.Select().
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where("t.area_id = ?", areaId).
+		Where(filter.where("t")))
+}
+
+// Sums up the test results selected by the builder per area or feature. A test is identified by its area, feature,
+// component, suite and file, and only its latest result in the coverage window is counted.
+// FirstTotal is the number of tests at the start of the coverage window, see firstTotal.
+// Results with the same test run are ordered by their ID, so the result inserted last is the latest one.
+// The failures of quarantined tests are not part of the failures, they are counted as quarantined.
+// Tests which passed after a retry are part of the passes and counted as retry passes as well.
+func (cs CoverageStore) getCoverage(groupBy string, builder sq.SelectBuilder) (map[int64]model.Test, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	builder := sq.Select("t.id", "t.product_id", "t.area_id", "t.feature_id", "t.suite", "t.file", "t.component", "t.url",
-		"t.total", "t.passes", "t.pending", "t.failures", "t.skipped", "t.uuid", "t.is_first", "t.testrun").
-		From("tests t").
-		Where("t.area_id = ?", areaId).
-		Where("t.testrun > ?", time.Now().AddDate(0, 0, -testQueryPeriodDays)).
-		OrderBy("t.feature_id", "t.component", "t.suite", "t.file", "t.testrun DESC")
-	query, args, err := builder.ToSql()
+	partition := "PARTITION BY t.area_id, t.feature_id, t.component, " + testSuiteColumn + ", " + testFileColumn
+	results := builder.Columns("t.product_id", "t.area_id", "t.feature_id", "t.total", "t.passes", "t.pending", "t.failures",
+		"t.skipped", "t.is_first", "q.id IS NOT NULL AS quarantined", "COALESCE(t.retry_passes,0) AS retry_passes",
+		"ROW_NUMBER() OVER ("+partition+" ORDER BY t.testrun DESC, t.id DESC) AS latest",
+		// FirstTotal of the latest result, see firstTotal
+		"FIRST_VALUE(t.total) OVER ("+partition+" ORDER BY t.testrun, t.id) - IF(t.is_first, t.total, 0) AS first_total").
+		LeftJoin(testQuarantineJoin, time.Now())
+	query, args, err := sq.Select("MIN(r.product_id)", "r."+groupBy,
+		"SUM(r.total)", "SUM(r.passes)", "SUM(r.pending)", "SUM(IF(r.quarantined, 0, r.failures))", "SUM(r.skipped)",
+		"SUM(r.first_total)", "SUM(IF(r.quarantined, r.failures, 0))", "SUM(r.retry_passes)").
+		FromSelect(results, "r").
+		Where("r.latest = 1").
+		GroupBy("r." + groupBy).
+		ToSql()
 	if err != nil {
 		return nil, err
 	}
@@ -295,41 +359,23 @@ func (cs CoverageStore) GetFeatureCoverageForArea(areaId string) (map[int64]mode
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
-	coverage := make(map[int64]model.Test)
-	var prevRow *model.Test
 
+	// Map where the key is the area or feature id
+	coverage := make(map[int64]model.Test)
 	for rows.Next() {
 		t := model.Test{}
-		if err := rows.Scan(&t.Id, &t.ProductId, &t.AreaId, &t.FeatureId, &t.Suite, &t.FileName, &t.Component, &t.Url, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun); err != nil {
+		var id int64
+		if err := rows.Scan(&t.ProductId, &id, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.FirstTotal,
+			&t.Quarantined, &t.RetryPasses); err != nil {
 			log.Println(err)
 			return nil, err
 		}
-
-		if r, ok := coverage[t.FeatureId]; ok {
-			if prevRow == nil || prevRow.Suite != t.Suite || prevRow.Component != t.Component || (prevRow.Component == t.Component && prevRow.FileName != t.FileName) {
-				// Only the latest test result is taken into account, if there more than one for suite and file
-				r.Total += t.Total
-				r.Passes += t.Passes
-				r.Pending += t.Pending
-				r.Failures += t.Failures
-				r.Skipped += t.Skipped
-				// When this test has not been executed in the last 28d for the first time, we use the total value
-				if !t.IsFirst {
-					r.FirstTotal += t.Total
-				}
-			} else {
-				// see GetAreaCoverageForProduct for explanation
-				r.FirstTotal = r.FirstTotal - prevRow.Total + t.Total
-			}
-			coverage[t.FeatureId] = r
+		if groupBy == "area_id" {
+			t.AreaId = id
 		} else {
-			// When this test has not been executed in the last 28d for the first time, we start with the total number of tests
-			if !t.IsFirst {
-				t.FirstTotal = t.Total
-			}
-			coverage[t.FeatureId] = t
+			t.FeatureId = id
 		}
-		prevRow = &t
+		coverage[id] = t
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -338,18 +384,20 @@ func (cs CoverageStore) GetFeatureCoverageForArea(areaId string) (map[int64]mode
 }
 
 // Get all tests for the specified suite and file
-func (cs CoverageStore) GetAllTestForSuiteFile(component string, suite string, file string) ([]model.Test, error) {
+func (cs CoverageStore) GetAllTestForSuiteFile(component string, suite string, file string, filter CoverageFilter) ([]model.Test, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	builder := sq.Select("id", "product_id", "suite", "file", "component", "url", "total", "passes", "pending",
-		"failures", "skipped", "uuid", "is_first", "testrun").
-		From("tests").
-		Where("component = ?", component).
-		Where("suite = ?", suite).
-		Where("file = ?", file).
-		Where("testrun > ?", time.Now().AddDate(0, 0, -testQueryPeriodDays)).
-		OrderBy("testrun DESC")
+	// Includes the results of the test before it was renamed
+	builder := sq.Select("t.id", "t.product_id", testSuiteColumn, testFileColumn, "t.component", "t.url", "t.total", "t.passes", "t.pending",
+		"t.failures", "t.skipped", "t.uuid", "t.is_first", "t.testrun", "COALESCE(t.retry_passes,0)").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where("t.component = ?", component).
+		Where(testSuiteColumn+" = ?", suite).
+		Where(testFileColumn+" = ?", file).
+		Where(filter.where("t")).
+		OrderBy("t.testrun DESC")
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, err
@@ -364,7 +412,7 @@ func (cs CoverageStore) GetAllTestForSuiteFile(component string, suite string, f
 	var tests = []model.Test{}
 	for rows.Next() {
 		t := model.Test{}
-		if err := rows.Scan(&t.Id, &t.ProductId, &t.Suite, &t.FileName, &t.Component, &t.Url, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun); err != nil {
+		if err := rows.Scan(&t.Id, &t.ProductId, &t.Suite, &t.FileName, &t.Component, &t.Url, &t.Total, &t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun, &t.RetryPasses); err != nil {
 			log.Printf("Error %s when query context", err)
 			return tests, err
 		}
@@ -377,19 +425,21 @@ func (cs CoverageStore) GetAllTestForSuiteFile(component string, suite string, f
 }
 
 // GetComponents retrieves all components with their latest test run statistics.
-func (cs CoverageStore) GetComponents() ([]model.Component, error) {
+func (cs CoverageStore) GetComponents(filter CoverageFilter) ([]model.Component, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	subquery := sq.Select("component", "MAX(testrun) AS testrun").
-		From("tests").
+		From("tests t").
+		Where(filter.where("t")).
 		GroupBy("component")
 	builder := sq.Select("c.component", "c.testrun",
 		"SUM(t.total) as total", "SUM(t.passes) as passes",
 		"SUM(t.pending) as pending", "SUM(t.failures) as failures",
-		"SUM(t.skipped) as skipped").
+		"SUM(t.skipped) as skipped", "COALESCE(SUM(t.retry_passes),0) as retry_passes").
 		FromSelect(subquery, "c").
 		Join("tests t ON c.component = t.component AND c.testrun = t.testrun").
+		Where(filter.where("t")).
 		GroupBy("c.component", "c.testrun").
 		OrderBy("c.component")
 	query, args, err := builder.ToSql()
@@ -406,7 +456,7 @@ func (cs CoverageStore) GetComponents() ([]model.Component, error) {
 	var components []model.Component
 	for rows.Next() {
 		var c model.Component
-		err := rows.Scan(&c.Name, &c.TestRun, &c.Total, &c.Passes, &c.Pending, &c.Failures, &c.Skipped)
+		err := rows.Scan(&c.Name, &c.TestRun, &c.Total, &c.Passes, &c.Pending, &c.Failures, &c.Skipped, &c.RetryPasses)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -421,10 +471,17 @@ func (cs CoverageStore) GetComponents() ([]model.Component, error) {
 	return components, nil
 }
 
-// DeleteTestsByFeatureId removes all tests associated with a specific feature
+// DeleteTestsByFeatureId removes all tests associated with a specific feature and their attachments
 const deleteTestsByFeatureIdStmt = "DELETE FROM tests WHERE feature_id = ?"
 
-func (cs CoverageStore) DeleteTestsByFeatureId(featureId string) (int64, error) {
+func (cs CoverageStore) DeleteTestsByFeatureId(featureId string) (int64, []model.Attachment, error) {
 	log.Printf("Deleting all tests for feature ID: %s", featureId)
-	return cs.executeSql(deleteTestsByFeatureIdStmt, featureId)
+	return cs.deleteTests(func(tx *CoverageStore) ([]model.Attachment, error) {
+		return tx.deleteTestResultAttachments("", "t.feature_id = ?", featureId)
+	}, deleteTestsByFeatureIdStmt, featureId)
+}
+
+// Returns the ID of the product of the test result
+func (cs CoverageStore) GetTestProductId(id string) (int64, error) {
+	return cs.queryId("SELECT product_id FROM tests WHERE id = ?;", id)
 }
@@ -0,0 +1,426 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+)
+
+// The coverage counts compared by the tests
+type counts struct {
+	Total, FirstTotal, Passes, Pending, Failures, Skipped int64
+}
+
+func countsOf(t model.Test) counts {
+	return counts{Total: t.Total, FirstTotal: t.FirstTotal, Passes: t.Passes, Pending: t.Pending, Failures: t.Failures,
+		Skipped: t.Skipped}
+}
+
+// The results of one test, the latest one first, as they are walked by GetTests
+func TestWalkTestResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []model.Test
+		want    model.Test
+	}{
+		{"one result", []model.Test{{Total: 4, Failures: 1}},
+			model.Test{FirstTotal: 4, TotalTestRuns: 1, FailedTestRuns: 1}},
+		{"one result which is the first upload", []model.Test{{Total: 4, IsFirst: true}},
+			model.Test{FirstTotal: 0, TotalTestRuns: 1}},
+		{"several results", []model.Test{{Total: 5}, {Total: 4, Failures: 2}, {Total: 3}},
+			model.Test{FirstTotal: 3, TotalTestRuns: 3, FailedTestRuns: 1}},
+		{"oldest result is the first upload", []model.Test{{Total: 5}, {Total: 3, IsFirst: true}},
+			model.Test{FirstTotal: 3, TotalTestRuns: 2}},
+		{"results which passed after a retry", []model.Test{{Total: 2, RetryPasses: 1}, {Total: 2}, {Total: 2, RetryPasses: 2}},
+			model.Test{FirstTotal: 2, TotalTestRuns: 3, RetriedTestRuns: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := initializeNewTest(tt.results[0])
+			for _, r := range tt.results[1:] {
+				updateExistingTest(&got, r)
+			}
+			if got.FirstTotal != tt.want.FirstTotal || got.TotalTestRuns != tt.want.TotalTestRuns ||
+				got.FailedTestRuns != tt.want.FailedTestRuns || got.RetriedTestRuns != tt.want.RetriedTestRuns {
+				t.Errorf("got first total %d, runs %d, failed runs %d, retried runs %d, want %d, %d, %d, %d", got.FirstTotal,
+					got.TotalTestRuns, got.FailedTestRuns, got.RetriedTestRuns, tt.want.FirstTotal, tt.want.TotalTestRuns,
+					tt.want.FailedTestRuns, tt.want.RetriedTestRuns)
+			}
+		})
+	}
+}
+
+// A test result of the fixtures of the coverage tests
+type testResultFixture struct {
+	feature  int
+	suite    string
+	file     string
+	total    int
+	passes   int
+	failures int
+	isFirst  bool
+	daysAgo  int
+}
+
+// The test results of the coverage tests, they are evaluated with and without database
+var coverageTests = []struct {
+	name    string
+	results []testResultFixture
+	aliases []model.TestAlias
+	// Coverage of the area, the features are compared with the row walk only
+	want counts
+}{
+	{
+		name: "one result per test",
+		results: []testResultFixture{
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 3, daysAgo: 5},
+			{feature: 1, suite: "cart", file: "cart.cy.js", total: 2, passes: 1, failures: 1, isFirst: true, daysAgo: 2},
+		},
+		want: counts{Total: 5, FirstTotal: 3, Passes: 4, Failures: 1},
+	},
+	{
+		name: "duplicate results",
+		results: []testResultFixture{
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 3, daysAgo: 5},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 3, daysAgo: 5},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 2, failures: 1, daysAgo: 1},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 2, failures: 1, daysAgo: 1},
+		},
+		want: counts{Total: 3, FirstTotal: 3, Passes: 2, Failures: 1},
+	},
+	{
+		name: "aliased tests",
+		results: []testResultFixture{
+			{feature: 0, suite: "login", file: "login.cy.js", total: 2, passes: 2, daysAgo: 10},
+			{feature: 0, suite: "sign in", file: "signin.cy.js", total: 4, passes: 3, failures: 1, daysAgo: 3},
+			{feature: 1, suite: "cart", file: "cart.cy.js", total: 1, passes: 1, daysAgo: 3},
+		},
+		aliases: []model.TestAlias{{OldSuite: "login", OldFile: "login.cy.js", NewSuite: "sign in", NewFile: "signin.cy.js"}},
+		want:    counts{Total: 5, FirstTotal: 3, Passes: 4, Failures: 1},
+	},
+	{
+		name: "several results in one testrun",
+		results: []testResultFixture{
+			{feature: 0, suite: "login", file: "login.cy.js", total: 2, passes: 2, daysAgo: 4},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 3, daysAgo: 4},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 4, passes: 3, failures: 1, daysAgo: 2},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 5, passes: 5, daysAgo: 2},
+			{feature: 1, suite: "cart", file: "cart.cy.js", total: 1, passes: 1, daysAgo: 2},
+		},
+		want: counts{Total: 6, FirstTotal: 3, Passes: 6},
+	},
+	{
+		name: "oldest result is the first upload",
+		results: []testResultFixture{
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 3, isFirst: true, daysAgo: 6},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 5, passes: 4, failures: 1, daysAgo: 1},
+			{feature: 1, suite: "cart", file: "cart.cy.js", total: 2, passes: 2, isFirst: true, daysAgo: 1},
+		},
+		want: counts{Total: 7, FirstTotal: 3, Passes: 6, Failures: 1},
+	},
+	{
+		name: "results outside of the coverage window",
+		results: []testResultFixture{
+			{feature: 0, suite: "login", file: "login.cy.js", total: 9, passes: 9, daysAgo: 40},
+			{feature: 0, suite: "login", file: "login.cy.js", total: 3, passes: 3, daysAgo: 6},
+			{feature: 1, suite: "cart", file: "cart.cy.js", total: 2, passes: 2, daysAgo: 35},
+		},
+		want: counts{Total: 3, FirstTotal: 3, Passes: 3},
+	},
+}
+
+// The window functions of getCoverage evaluated without database, compared with the row walk and with the walk of GetTests
+func TestCoverageWindowFunctions(t *testing.T) {
+	for _, tt := range coverageTests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := fixtureRows(tt.results, tt.aliases, DefaultCoverageFilter(DefaultCoverageDays))
+
+			areas := windowCoverage(rows, "area_id")
+			if got := countsOf(areas[1]); got != tt.want {
+				t.Errorf("area coverage %+v, want %+v", got, tt.want)
+			}
+			compareCoverage(t, areas, walkCoverage(rows, "area_id"))
+			compareCoverage(t, windowCoverage(rows, "feature_id"), walkCoverage(rows, "feature_id"))
+
+			// FirstTotal of the latest result of a test is the one of the walk of GetTests
+			for key, results := range partitionRows(rows) {
+				want := initializeNewTest(results[0])
+				for _, r := range results[1:] {
+					updateExistingTest(&want, r)
+				}
+				if got := windowFirstTotal(results); got != want.FirstTotal {
+					t.Errorf("first total of %s is %d, the walk of GetTests has %d", key, got, want.FirstTotal)
+				}
+			}
+		})
+	}
+}
+
+func TestGetCoverage(t *testing.T) {
+	cs := testCoverageStore(t)
+
+	for i, tt := range coverageTests {
+		t.Run(tt.name, func(t *testing.T) {
+			pid, aid := insertCoverageFixtures(t, cs, fmt.Sprintf("Product %d", i), tt.results, tt.aliases)
+			filter := DefaultCoverageFilter(DefaultCoverageDays)
+
+			areas, err := cs.GetAreaCoverageForProduct(pid, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := countsOf(areas[aid]); got != tt.want {
+				t.Errorf("area coverage %+v, want %+v", got, tt.want)
+			}
+			walk := rowWalkCoverage(t, cs, "area_id", sq.And{sq.Eq{"t.product_id": pid}, filter.where("t")})
+			compareCoverage(t, areas, walk)
+
+			features, err := cs.GetFeatureCoverageForArea(fmt.Sprint(aid), filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			walk = rowWalkCoverage(t, cs, "feature_id", sq.And{sq.Eq{"t.area_id": aid}, filter.where("t")})
+			compareCoverage(t, features, walk)
+		})
+	}
+}
+
+func compareCoverage(t *testing.T, got map[int64]model.Test, want map[int64]model.Test) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("coverage of %d areas or features, the row walk has %d", len(got), len(want))
+	}
+	for id, w := range want {
+		if g := countsOf(got[id]); g != countsOf(w) {
+			t.Errorf("coverage of %d is %+v, the row walk has %+v", id, g, countsOf(w))
+		}
+	}
+}
+
+// The row walk which aggregated the coverage before it was done with window functions. Rows of the same test follow
+// each other, the latest one first. Only the first row of a test is counted, FirstTotal is corrected by the older ones.
+func rowWalkCoverage(t *testing.T, cs *CoverageStore, groupBy string, where sq.Sqlizer) map[int64]model.Test {
+	t.Helper()
+	query, args, err := sq.Select("t.area_id", "t.feature_id", "t.component", testSuiteColumn, testFileColumn, "t.total",
+		"t.passes", "t.pending", "t.failures", "t.skipped", "t.is_first").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where(where).
+		OrderBy("t.area_id", "t.feature_id", "t.component", testSuiteColumn, testFileColumn, "t.testrun DESC", "t.id DESC").
+		ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := cs.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	results := []model.Test{}
+	for rows.Next() {
+		r := model.Test{}
+		if err := rows.Scan(&r.AreaId, &r.FeatureId, &r.Component, &r.Suite, &r.FileName, &r.Total, &r.Passes, &r.Pending,
+			&r.Failures, &r.Skipped, &r.IsFirst); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return walkCoverage(results, groupBy)
+}
+
+// The row walk on the results ordered by area, feature, component, suite and file, the latest result of a test first
+func walkCoverage(rows []model.Test, groupBy string) map[int64]model.Test {
+	coverage := make(map[int64]model.Test)
+	var prevRow *model.Test
+	for _, r := range rows {
+		id := groupId(r, groupBy)
+		c := coverage[id]
+		if prevRow == nil || prevRow.AreaId != r.AreaId || prevRow.FeatureId != r.FeatureId || prevRow.Component != r.Component ||
+			prevRow.Suite != r.Suite || prevRow.FileName != r.FileName {
+			c.Total += r.Total
+			c.Passes += r.Passes
+			c.Pending += r.Pending
+			c.Failures += r.Failures
+			c.Skipped += r.Skipped
+			if !r.IsFirst {
+				c.FirstTotal += r.Total
+			}
+		} else {
+			c.FirstTotal = c.FirstTotal - prevRow.Total + r.Total
+		}
+		coverage[id] = c
+		prevRow = &r
+	}
+	return coverage
+}
+
+// The aggregation of getCoverage: the latest result of every test, with FirstTotal of the window function, summed up
+// per area or feature
+func windowCoverage(rows []model.Test, groupBy string) map[int64]model.Test {
+	coverage := make(map[int64]model.Test)
+	for _, results := range partitionRows(rows) {
+		latest := results[0]
+		id := groupId(latest, groupBy)
+		c := coverage[id]
+		c.Total += latest.Total
+		c.Passes += latest.Passes
+		c.Pending += latest.Pending
+		c.Failures += latest.Failures
+		c.Skipped += latest.Skipped
+		c.FirstTotal += windowFirstTotal(results)
+		coverage[id] = c
+	}
+	return coverage
+}
+
+// FIRST_VALUE(t.total) OVER (partition ORDER BY t.testrun, t.id) - IF(t.is_first, t.total, 0) of the latest result of
+// a test, its results are ordered with the latest one first
+func windowFirstTotal(results []model.Test) int64 {
+	first := results[len(results)-1].Total
+	if results[0].IsFirst {
+		return first - results[0].Total
+	}
+	return first
+}
+
+// The results of every test, in the order of the rows
+func partitionRows(rows []model.Test) map[string][]model.Test {
+	partitions := make(map[string][]model.Test)
+	for _, r := range rows {
+		key := fmt.Sprintf("%d/%d/%s/%s/%s", r.AreaId, r.FeatureId, r.Component, r.Suite, r.FileName)
+		partitions[key] = append(partitions[key], r)
+	}
+	return partitions
+}
+
+func groupId(r model.Test, groupBy string) int64 {
+	if groupBy == "feature_id" {
+		return r.FeatureId
+	}
+	return r.AreaId
+}
+
+// The results of the fixtures as they are selected by getCoverage in area 1: with the suite and file after a rename and
+// only in the coverage window. They are ordered like the rows of the row walk.
+func fixtureRows(results []testResultFixture, aliases []model.TestAlias, filter CoverageFilter) []model.Test {
+	today := time.Now().Truncate(24 * time.Hour)
+	rows := []model.Test{}
+	for i, r := range results {
+		row := model.Test{Id: int64(i + 1), AreaId: 1, FeatureId: int64(r.feature + 1), Component: "web", Suite: r.suite,
+			FileName: r.file, Total: int64(r.total), Passes: int64(r.passes), Failures: int64(r.failures), IsFirst: r.isFirst,
+			TestRun: today.AddDate(0, 0, -r.daysAgo)}
+		for _, a := range aliases {
+			if a.OldSuite == row.Suite && a.OldFile == row.FileName {
+				row.Suite, row.FileName = a.NewSuite, a.NewFile
+			}
+		}
+		if row.TestRun.Before(filter.From) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	slices.SortFunc(rows, func(a, b model.Test) int {
+		return cmp.Or(cmp.Compare(a.AreaId, b.AreaId), cmp.Compare(a.FeatureId, b.FeatureId), strings.Compare(a.Component, b.Component),
+			strings.Compare(a.Suite, b.Suite), strings.Compare(a.FileName, b.FileName), b.TestRun.Compare(a.TestRun), cmp.Compare(b.Id, a.Id))
+	})
+	return rows
+}
+
+// Inserts a product with one area, two features and the test results, returns the IDs of the product and the area
+func insertCoverageFixtures(t *testing.T, cs *CoverageStore, name string, results []testResultFixture, aliases []model.TestAlias) (string, int64) {
+	t.Helper()
+	productId, err := cs.InsertProduct(model.Product{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	areaId, err := cs.InsertArea(model.Area{ProductId: productId, Name: "Area"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var featureIds []int64
+	for _, f := range []string{"Feature A", "Feature B"} {
+		id, err := cs.InsertFeature(model.Feature{AreaId: areaId, Name: f})
+		if err != nil {
+			t.Fatal(err)
+		}
+		featureIds = append(featureIds, id)
+	}
+	run := model.Run{ProductId: productId, StartedAt: time.Now(), EndedAt: time.Now()}
+	if run.Id, err = cs.InsertRun(run); err != nil {
+		t.Fatal(err)
+	}
+
+	pid := fmt.Sprint(productId)
+	today := time.Now().Truncate(24 * time.Hour)
+	for i, r := range results {
+		tr := reporter.TestResult{Suite: r.suite, File: r.file, Total: r.total, Passes: r.passes, Failures: r.failures,
+			Uuid: fmt.Sprintf("%s-%d", name, i), TestRun: today.AddDate(0, 0, -r.daysAgo)}
+		if _, err := cs.InsertTestResult(pid, areaId, featureIds[r.feature], "web", "", r.isFirst, run, tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, a := range aliases {
+		a.ProductId, a.Component = productId, "web"
+		if _, err := cs.InsertTestAlias(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return pid, areaId
+}
+
+// The tests against MySQL 8 need the DSN of the server in E2E_COVERAGE_TEST_DSN, e.g. user:password@tcp(localhost:3306).
+// The database e2ecoverage_test is created for the tests and dropped afterwards.
+func testCoverageStore(t *testing.T) *CoverageStore {
+	t.Helper()
+	dsn := os.Getenv("E2E_COVERAGE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("E2E_COVERAGE_TEST_DSN is not set")
+	}
+	server, err := sql.Open("mysql", dsn+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	for _, stmt := range []string{"DROP DATABASE IF EXISTS e2ecoverage_test", "CREATE DATABASE e2ecoverage_test"} {
+		if _, err := server.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		if _, err := server.Exec("DROP DATABASE IF EXISTS e2ecoverage_test"); err != nil {
+			t.Log(err)
+		}
+	})
+
+	database, err := sql.Open("mysql", dsn+"/e2ecoverage_test?parseTime=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	cs := WithDB(database)
+	if err := cs.CreateAllTables(); err != nil {
+		t.Fatal(err)
+	}
+	return cs
+}
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+const createTestAliasStmt = `CREATE TABLE IF NOT EXISTS test_aliases (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT,
+	component VARCHAR(255),
+	old_suite VARCHAR(255),
+	old_file VARCHAR(255),
+	new_suite VARCHAR(255),
+	new_file VARCHAR(255),
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY (product_id, component, old_suite, old_file),
+	FOREIGN KEY (product_id) REFERENCES products(id)
+	)`
+
+// Joins the alias of a test, so the test results of an old suite and file are part of the new test.
+// Aliases always point to the latest suite and file, so a rename of a renamed test needs only one join.
+const testAliasJoin = "test_aliases al ON al.product_id = t.product_id AND al.component = t.component AND al.old_suite = t.suite AND al.old_file = t.file"
+
+// Suite and file of a test after a rename
+const (
+	testSuiteColumn = "COALESCE(al.new_suite, t.suite)"
+	testFileColumn  = "COALESCE(al.new_file, t.file)"
+)
+
+const upsertTestAliasStmt = `INSERT INTO test_aliases (product_id, component, old_suite, old_file, new_suite, new_file) VALUES (?,?,?,?,?,?)
+	ON DUPLICATE KEY UPDATE new_suite = VALUES(new_suite), new_file = VALUES(new_file), id = LAST_INSERT_ID(id)`
+
+// Aliases pointing to the old suite and file are changed to point to the new one
+const updateTestAliasChainStmt = `UPDATE test_aliases SET new_suite = ?, new_file = ?
+	WHERE product_id = ? AND component = ? AND new_suite = ? AND new_file = ?`
+
+const deleteTestAliasStmt = "DELETE FROM test_aliases WHERE id = ?"
+
+const deleteTestAliasByOldStmt = "DELETE FROM test_aliases WHERE product_id = ? AND component = ? AND old_suite = ? AND old_file = ?"
+
+// The first upload of the new suite and file is not the first upload of the test, if there are results of the old ones
+const updateRenamedFirstUploadsStmt = `UPDATE tests t
+	JOIN tests o ON o.product_id = t.product_id AND o.component = t.component AND o.area_id = t.area_id
+		AND o.feature_id = t.feature_id AND o.suite = ? AND o.file = ? AND o.testrun < t.testrun
+	SET t.is_first = FALSE
+	WHERE t.product_id = ? AND t.component = ? AND t.suite = ? AND t.file = ? AND t.is_first`
+
+const resetRenamedFirstUploadsStmt = `UPDATE tests t
+	JOIN (SELECT area_id, feature_id, MIN(testrun) AS first_run FROM tests
+		WHERE product_id = ? AND component = ? AND suite = ? AND file = ? GROUP BY area_id, feature_id) f
+	ON f.area_id = t.area_id AND f.feature_id = t.feature_id
+	SET t.is_first = (t.testrun = f.first_run)
+	WHERE t.product_id = ? AND t.component = ? AND t.suite = ? AND t.file = ?`
+
+func (cs CoverageStore) CreateTestAliasesTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createTestAliasStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Test Aliases DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+// Stores the alias in one transaction together with the aliases and first uploads it changes. The new suite and file
+// are resolved first, if they were renamed as well.
+func (cs CoverageStore) InsertTestAlias(a model.TestAlias) (model.TestAlias, error) {
+	if a.OldSuite == a.NewSuite && a.OldFile == a.NewFile {
+		return a, fmt.Errorf("suite %s and file %s are an alias of themselves", a.OldSuite, a.OldFile)
+	}
+	err := cs.WithTx(func(tx *CoverageStore) error {
+		pid := fmt.Sprint(a.ProductId)
+		newSuite, newFile, err := tx.ResolveTestAlias(pid, a.Component, a.NewSuite, a.NewFile)
+		if err != nil {
+			return err
+		}
+		if newSuite == a.OldSuite && newFile == a.OldFile {
+			// The test is renamed back, so the alias of the new suite and file is not needed anymore
+			if _, err := tx.executeSql(deleteTestAliasByOldStmt, a.ProductId, a.Component, a.NewSuite, a.NewFile); err != nil {
+				return fmt.Errorf("error deleting test alias: %w", err)
+			}
+		} else {
+			a.NewSuite, a.NewFile = newSuite, newFile
+		}
+
+		if a.Id, err = tx.executeSql(upsertTestAliasStmt, a.ProductId, a.Component, a.OldSuite, a.OldFile, a.NewSuite, a.NewFile); err != nil {
+			return err
+		}
+		if _, err := tx.executeSql(updateTestAliasChainStmt, a.NewSuite, a.NewFile, a.ProductId, a.Component, a.OldSuite, a.OldFile); err != nil {
+			return fmt.Errorf("error updating test aliases: %w", err)
+		}
+		if _, err := tx.executeSql(updateRenamedFirstUploadsStmt, a.OldSuite, a.OldFile, a.ProductId, a.Component, a.NewSuite, a.NewFile); err != nil {
+			return fmt.Errorf("error updating first uploads: %w", err)
+		}
+		return nil
+	})
+	return a, err
+}
+
+// Deletes the alias in one transaction with the reset of the first uploads and returns the number of deleted rows. The
+// first upload of the new suite and file is the first upload of the test again.
+func (cs CoverageStore) DeleteTestAlias(id string) (int64, error) {
+	var affected int64
+	err := cs.WithTx(func(tx *CoverageStore) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var a model.TestAlias
+		err := tx.db.QueryRowContext(ctx, "SELECT product_id, component, new_suite, new_file FROM test_aliases WHERE id = ?;", id).
+			Scan(&a.ProductId, &a.Component, &a.NewSuite, &a.NewFile)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error getting test alias: %w", err)
+		}
+
+		res, err := tx.db.ExecContext(ctx, deleteTestAliasStmt, id)
+		if err != nil {
+			return fmt.Errorf("error deleting test alias: %w", err)
+		}
+		if affected, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("error finding rows affected: %w", err)
+		}
+		if _, err := tx.executeSql(resetRenamedFirstUploadsStmt, a.ProductId, a.Component, a.NewSuite, a.NewFile, a.ProductId, a.Component, a.NewSuite, a.NewFile); err != nil {
+			return fmt.Errorf("error updating first uploads: %w", err)
+		}
+		return nil
+	})
+	return affected, err
+}
+
+// Returns the current suite and file of a test. Suite and file are returned unchanged, if the test was not renamed.
+func (cs CoverageStore) ResolveTestAlias(productId string, component string, suite string, file string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var newSuite, newFile string
+	err := cs.db.QueryRowContext(ctx, "SELECT new_suite, new_file FROM test_aliases WHERE product_id = ? AND component = ? AND old_suite = ? AND old_file = ?;",
+		productId, component, suite, file).Scan(&newSuite, &newFile)
+	if err == sql.ErrNoRows {
+		return suite, file, nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("error resolving test alias: %w", err)
+	}
+	return newSuite, newFile, nil
+}
+
+// Get all test aliases of the specified product
+func (cs CoverageStore) GetTestAliases(productId string) ([]model.TestAlias, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := cs.db.QueryContext(ctx, "SELECT id, product_id, component, old_suite, old_file, new_suite, new_file FROM test_aliases WHERE product_id = ? ORDER BY component, new_suite, new_file;", productId)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var aliases = []model.TestAlias{}
+	for rows.Next() {
+		a := model.TestAlias{}
+		if err := rows.Scan(&a.Id, &a.ProductId, &a.Component, &a.OldSuite, &a.OldFile, &a.NewSuite, &a.NewFile); err != nil {
+			log.Println(err)
+			return aliases, err
+		}
+		aliases = append(aliases, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
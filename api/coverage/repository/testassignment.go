@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+const createTestAssignmentStmt = `CREATE TABLE IF NOT EXISTS test_assignments (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT,
+	component VARCHAR(255),
+	suite VARCHAR(255),
+	file VARCHAR(255),
+	area_id INT,
+	feature_id INT,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY (product_id, component, suite, file),
+	FOREIGN KEY (product_id) REFERENCES products(id),
+	FOREIGN KEY (area_id) REFERENCES areas(id) ON DELETE CASCADE,
+	FOREIGN KEY (feature_id) REFERENCES features(id) ON DELETE CASCADE
+	)`
+
+// An existing assignment of the same test is replaced. LAST_INSERT_ID returns the id of the existing row in this case.
+const upsertTestAssignmentStmt = `INSERT INTO test_assignments (product_id, component, suite, file, area_id, feature_id) VALUES (?,?,?,?,?,?)
+	ON DUPLICATE KEY UPDATE area_id = VALUES(area_id), feature_id = VALUES(feature_id), id = LAST_INSERT_ID(id)`
+
+const deleteTestAssignmentStmt = "DELETE FROM test_assignments WHERE id = ?"
+
+const assignTestsStmt = `UPDATE tests SET area_id = ?, feature_id = ?, unmapped_id = NULL
+	WHERE product_id = ? AND component = ? AND suite = ? AND file = ? AND area_id IS NULL`
+
+func (cs CoverageStore) CreateTestAssignmentsTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createTestAssignmentStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Test Assignments DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+// Stores the assignment and assigns all stored tests of the component, suite and file which have no area and feature yet
+func (cs CoverageStore) AssignTests(ta model.TestAssignment) (int64, error) {
+	id, err := cs.executeSql(upsertTestAssignmentStmt, ta.ProductId, ta.Component, ta.Suite, ta.File, ta.AreaId, ta.FeatureId)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := cs.executeSql(assignTestsStmt, ta.AreaId, ta.FeatureId, ta.ProductId, ta.Component, ta.Suite, ta.File); err != nil {
+		return 0, fmt.Errorf("error assigning tests: %w", err)
+	}
+	return id, nil
+}
+
+// Deletes the assignment and returns the number of deleted rows
+func (cs CoverageStore) DeleteTestAssignment(id string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := cs.db.ExecContext(ctx, deleteTestAssignmentStmt, id)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting test assignment: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// Returns the assignment of the test, area and feature ID are 0 if the test has not been assigned
+func (cs CoverageStore) GetTestAssignment(productId string, component string, suite string, file string) (int64, int64, error) {
+	assignments, err := cs.getTestAssignments(sq.Eq{"product_id": productId, "component": component, "suite": suite, "file": file})
+	if err != nil || len(assignments) == 0 {
+		return 0, 0, err
+	}
+	return assignments[0].AreaId, assignments[0].FeatureId, nil
+}
+
+// Get all test assignments of the specified product
+func (cs CoverageStore) GetTestAssignments(productId string) ([]model.TestAssignment, error) {
+	return cs.getTestAssignments(sq.Eq{"product_id": productId})
+}
+
+func (cs CoverageStore) getTestAssignments(where sq.Eq) ([]model.TestAssignment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("id", "product_id", "component", "suite", "file", "area_id", "feature_id").
+		From("test_assignments").
+		Where(where).
+		OrderBy("component", "suite", "file")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var assignments = []model.TestAssignment{}
+	for rows.Next() {
+		ta := model.TestAssignment{}
+		if err := rows.Scan(&ta.Id, &ta.ProductId, &ta.Component, &ta.Suite, &ta.File, &ta.AreaId, &ta.FeatureId); err != nil {
+			log.Println(err)
+			return assignments, err
+		}
+		assignments = append(assignments, ta)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// Returns component, suite and file of all unassigned tests of the product matching the filter
+func (cs CoverageStore) GetUnassignedTests(productId string, filter model.TestAssignmentFilter) ([]model.TestAssignment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("DISTINCT component", "suite", "file").
+		From("tests").
+		Where("product_id = ?", productId).
+		Where("area_id IS NULL").
+		OrderBy("component", "suite", "file")
+	if filter.Component != "" {
+		builder = builder.Where("component = ?", filter.Component)
+	}
+	if filter.Suite != "" {
+		builder = builder.Where(sq.Like{"suite": likeContains(filter.Suite)})
+	}
+	if filter.File != "" {
+		builder = builder.Where(sq.Like{"file": likeContains(filter.File)})
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var tests = []model.TestAssignment{}
+	for rows.Next() {
+		ta := model.TestAssignment{}
+		if err := rows.Scan(&ta.Component, &ta.Suite, &ta.File); err != nil {
+			log.Println(err)
+			return tests, err
+		}
+		tests = append(tests, ta)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
+
+// Pattern of a LIKE condition matching values which contain the value. Wildcards in the value are matched literally.
+func likeContains(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+	"github.com/TestAndWin/e2e-coverage/coverage/reporter"
+)
+
+const createTestCaseStmt = `CREATE TABLE IF NOT EXISTS test_cases (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	test_id INT,
+	title TEXT,
+	full_title TEXT,
+	state VARCHAR(20),
+	duration INT,
+	error_message TEXT,
+	stack MEDIUMTEXT,
+	diff MEDIUMTEXT,
+	signature CHAR(40),
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	FOREIGN KEY (test_id) REFERENCES tests(id) ON DELETE CASCADE
+	)`
+
+const selectTestCase = "SELECT tc.id, tc.test_id, tc.title, tc.full_title, tc.state, tc.duration, COALESCE(tc.error_message,''), COALESCE(tc.stack,''), COALESCE(tc.diff,''), COALESCE(tc.signature,'')"
+
+// Max. number of test cases inserted with one statement
+const testCaseInsertBatchSize = 500
+
+func (cs CoverageStore) CreateTestCasesTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createTestCaseStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Test Cases DB table\n", err)
+		return err
+	}
+	if err := cs.addColumnIfNotExists("test_cases", "diff", "MEDIUMTEXT"); err != nil {
+		return err
+	}
+	return cs.addColumnIfNotExists("test_cases", "signature", "CHAR(40)")
+}
+
+// Inserts the test cases of the test with the specified id. The failure signature of a failed test case is stored as well.
+func (cs CoverageStore) InsertTestCases(testId int64, cases []reporter.TestCase) error {
+	for start := 0; start < len(cases); start += testCaseInsertBatchSize {
+		end := min(start+testCaseInsertBatchSize, len(cases))
+
+		builder := sq.Insert("test_cases").Columns("test_id", "title", "full_title", "state", "duration", "error_message", "stack",
+			"diff", "signature")
+		for _, tc := range cases[start:end] {
+			var signature any
+			if tc.State == reporter.StateFailed {
+				signature = reporter.FailureSignature(tc.ErrorMessage, tc.Stack)
+			}
+			builder = builder.Values(testId, tc.Title, tc.FullTitle, tc.State, tc.Duration, tc.ErrorMessage, tc.Stack, tc.Diff,
+				signature)
+		}
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := cs.executeSql(query, args...); err != nil {
+			return fmt.Errorf("error inserting test cases: %w", err)
+		}
+	}
+	return nil
+}
+
+// Get all test cases of the specified test
+func (cs CoverageStore) GetTestCases(testId string) ([]model.TestCase, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := cs.db.QueryContext(ctx, selectTestCase+" FROM test_cases tc WHERE tc.test_id = ? ORDER BY tc.id;", testId)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var cases = []model.TestCase{}
+	for rows.Next() {
+		tc := model.TestCase{}
+		if err := rows.Scan(&tc.Id, &tc.TestId, &tc.Title, &tc.FullTitle, &tc.State, &tc.Duration, &tc.ErrorMessage, &tc.Stack,
+			&tc.Diff, &tc.Signature); err != nil {
+			log.Println(err)
+			return cases, err
+		}
+		cases = append(cases, tc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// Get all results of the test in the window, the oldest first. The results of a renamed test are included.
+func (cs CoverageStore) GetTestHistory(productId string, component string, suite string, file string, filter CoverageFilter) ([]model.TestHistoryEntry, error) {
+	return cs.getTestHistory(productId, component, suite, file, filter, 0)
+}
+
+// Get the latest results of the test, the oldest first. Only the branch and environment of the filter are used.
+func (cs CoverageStore) GetLatestTestResults(productId string, component string, suite string, file string, filter CoverageFilter, limit uint64) ([]model.TestHistoryEntry, error) {
+	return cs.getTestHistory(productId, component, suite, file, CoverageFilter{Branch: filter.Branch, Environment: filter.Environment,
+		IncludeEmptyBranch: filter.IncludeEmptyBranch}, limit)
+}
+
+// Without limit all results are returned
+func (cs CoverageStore) getTestHistory(productId string, component string, suite string, file string, filter CoverageFilter, limit uint64) ([]model.TestHistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	suite, file, err := cs.ResolveTestAlias(productId, component, suite, file)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := sq.Select("t.id", "COALESCE(t.run_id,0)", "COALESCE(r.commit_sha,'')", "COALESCE(t.branch,'')", "COALESCE(t.environment,'')",
+		"t.testrun", "t.total", "t.passes", "t.pending", "t.failures", "t.skipped",
+		"COALESCE(t.retry_passes,0)", "COALESCE(t.duration,0)", "t.url").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		LeftJoin("runs r ON r.id = t.run_id").
+		Where("t.product_id = ?", productId).
+		Where("t.component = ?", component).
+		Where(testSuiteColumn+" = ?", suite).
+		Where(testFileColumn+" = ?", file).
+		Where(filter.where("t")).
+		OrderBy("t.testrun DESC", "t.id DESC")
+	if limit > 0 {
+		builder = builder.Limit(limit)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	defer rows.Close()
+	var history = []model.TestHistoryEntry{}
+	for rows.Next() {
+		e := model.TestHistoryEntry{}
+		if err := rows.Scan(&e.TestId, &e.RunId, &e.CommitSha, &e.Branch, &e.Environment, &e.TestRun, &e.Total, &e.Passes,
+			&e.Pending, &e.Failures, &e.Skipped, &e.RetryPasses, &e.Duration, &e.Url); err != nil {
+			log.Println(err)
+			return history, err
+		}
+		e.Status = testResultStatus(e.Passes, e.Failures, e.RetryPasses)
+		history = append(history, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// The latest results are selected, but returned in the order they were executed
+	slices.Reverse(history)
+	return history, nil
+}
+
+// A result with a failed test is failed, a result with a test which passed after a retry and without failures is
+// passed after retry, a result with a passed test and without failures is passed
+func testResultStatus(passes int64, failures int64, retryPasses int64) string {
+	switch {
+	case failures > 0:
+		return model.TestFailed
+	case retryPasses > 0:
+		return model.TestPassedAfterRetry
+	case passes > 0:
+		return model.TestPassed
+	default:
+		return model.TestSkipped
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+// Status filters of a test list
+const (
+	// The latest result of the test has failures
+	TestStatusFailing = "failing"
+	// The latest result of the test has no failures
+	TestStatusPassing = "passing"
+	// The test has failed and passed results or results which passed after a retry in the coverage window
+	TestStatusFlaky = "flaky"
+)
+
+// Sort orders of a test list
+const (
+	TestSortName        = "name"
+	TestSortLastRun     = "last-run"
+	TestSortFailureRate = "failure-rate"
+)
+
+// TestQuery filters, sorts and pages the tests of a product. Empty values do not filter.
+type TestQuery struct {
+	Component  string
+	AreaId     int64
+	FeatureId  int64
+	Unassigned bool
+	Status     string
+	// Part of the suite or file name
+	Search string
+	Sort   string
+	Desc   bool
+	// Without limit all tests are returned
+	Limit  uint64
+	Offset uint64
+}
+
+// Get the tests of the product in the coverage window. A test is identified by its component, suite and file, its latest
+// result is returned together with the number of runs, failed runs and runs with tests which passed after a retry in the
+// window. Area and feature are the ones of the latest result. The total number of tests matching the query is returned
+// as well, it is only counted if the query has a limit.
+func (cs CoverageStore) GetProductTests(pid string, filter CoverageFilter, q TestQuery) ([]model.Test, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	partition := "PARTITION BY t.component, " + testSuiteColumn + ", " + testFileColumn
+	results := sq.Select("t.id", "t.product_id", "t.area_id", "t.feature_id", testSuiteColumn+" AS suite", testFileColumn+" AS file",
+		"t.component", "t.url", "t.total", "t.passes", "t.pending", "t.failures", "t.skipped", "t.uuid", "t.is_first", "t.testrun",
+		"COALESCE(t.retry_passes,0) AS retry_passes",
+		"ROW_NUMBER() OVER ("+partition+" ORDER BY t.testrun DESC, t.id DESC) AS latest",
+		"COUNT(*) OVER ("+partition+") AS total_runs",
+		"SUM(IF(t.failures > 0, 1, 0)) OVER ("+partition+") AS failed_runs",
+		"SUM(IF(t.retry_passes > 0, 1, 0)) OVER ("+partition+") AS retried_runs",
+		// FirstTotal of the latest result, see firstTotal
+		"FIRST_VALUE(t.total) OVER ("+partition+" ORDER BY t.testrun, t.id) - IF(t.is_first, t.total, 0) AS first_total").
+		From("tests t").
+		LeftJoin(testAliasJoin).
+		Where("t.product_id = ?", pid).
+		Where(filter.where("t"))
+	if q.Component != "" {
+		results = results.Where("t.component = ?", q.Component)
+	}
+	if q.Search != "" {
+		search := likeContains(q.Search)
+		results = results.Where(sq.Or{sq.Like{testSuiteColumn: search}, sq.Like{testFileColumn: search}})
+	}
+
+	tests := sq.Select().
+		FromSelect(results, "r").
+		Where("r.latest = 1")
+	if q.AreaId != 0 {
+		tests = tests.Where("r.area_id = ?", q.AreaId)
+	}
+	if q.FeatureId != 0 {
+		tests = tests.Where("r.feature_id = ?", q.FeatureId)
+	}
+	if q.Unassigned {
+		tests = tests.Where("r.area_id IS NULL")
+	}
+	switch q.Status {
+	case TestStatusFailing:
+		tests = tests.Where("r.failures > 0")
+	case TestStatusPassing:
+		tests = tests.Where("r.failures = 0")
+	case TestStatusFlaky:
+		tests = tests.Where("(r.failed_runs > 0 AND r.failed_runs < r.total_runs) OR r.retried_runs > 0")
+	}
+
+	var count int64
+	if q.Limit > 0 {
+		query, args, err := sq.Select("COUNT(*)").FromSelect(tests.Columns("r.id"), "c").ToSql()
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := cs.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+			log.Printf("Error %s when query context", err)
+			return nil, 0, fmt.Errorf("failed to count tests: %w", err)
+		}
+	}
+
+	tests = tests.Columns("r.id", "r.product_id", "COALESCE(r.area_id,0)", "COALESCE(r.feature_id,0)", "r.suite", "r.file",
+		"r.component", "r.url", "r.total", "r.passes", "r.pending", "r.failures", "r.skipped", "r.uuid", "r.is_first", "r.testrun",
+		"r.failed_runs", "r.total_runs", "r.first_total", "r.retry_passes", "r.retried_runs").
+		OrderBy(testOrder(q)...)
+	if q.Limit > 0 {
+		tests = tests.Limit(q.Limit).Offset(q.Offset)
+	}
+	query, args, err := tests.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var list []model.Test
+	for rows.Next() {
+		t := model.Test{}
+		if err := rows.Scan(&t.Id, &t.ProductId, &t.AreaId, &t.FeatureId, &t.Suite, &t.FileName, &t.Component, &t.Url, &t.Total,
+			&t.Passes, &t.Pending, &t.Failures, &t.Skipped, &t.Uuid, &t.IsFirst, &t.TestRun, &t.FailedTestRuns, &t.TotalTestRuns,
+			&t.FirstTotal, &t.RetryPasses, &t.RetriedTestRuns); err != nil {
+			log.Printf("Error %s when query context", err)
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		list = append(list, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	if q.Limit == 0 {
+		count = int64(len(list))
+	}
+	return list, count, nil
+}
+
+// Returns the order of the test list. The test identity is always part of the order, so the pages are stable.
+func testOrder(q TestQuery) []string {
+	direction := " ASC"
+	if q.Desc {
+		direction = " DESC"
+	}
+	switch q.Sort {
+	case TestSortName:
+		return []string{"r.suite" + direction, "r.file" + direction, "r.component" + direction}
+	case TestSortLastRun:
+		return []string{"r.testrun" + direction, "r.component", "r.suite", "r.file"}
+	case TestSortFailureRate:
+		return []string{"r.failed_runs / r.total_runs" + direction, "r.component", "r.suite", "r.file"}
+	default:
+		return []string{"r.component", "r.suite", "r.file"}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2022-2026, webmaster@testandwin.net, Michael Schlottmann
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/TestAndWin/e2e-coverage/coverage/model"
+)
+
+const createUnmappedTestStmt = `CREATE TABLE IF NOT EXISTS unmapped_tests (
+	id INT AUTO_INCREMENT PRIMARY KEY,
+	product_id INT,
+	area VARCHAR(255),
+	feature VARCHAR(255),
+	status VARCHAR(20),
+	area_id INT,
+	feature_id INT,
+	occurrences INT,
+	first_seen DATETIME,
+	last_seen DATETIME,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	UNIQUE KEY (product_id, area, feature),
+	FOREIGN KEY (product_id) REFERENCES products(id),
+	FOREIGN KEY (area_id) REFERENCES areas(id) ON DELETE SET NULL,
+	FOREIGN KEY (feature_id) REFERENCES features(id) ON DELETE SET NULL
+	)`
+
+const insertUnmappedTestStmt = "INSERT INTO unmapped_tests (product_id, area, feature, status, occurrences, first_seen, last_seen) VALUES (?,?,?,?,1,?,?)"
+
+const countUnmappedTestStmt = "UPDATE unmapped_tests SET occurrences = occurrences + 1, last_seen = ? WHERE id = ?"
+
+const acceptUnmappedTestStmt = "UPDATE unmapped_tests SET status = ?, area_id = ?, feature_id = ? WHERE id = ?"
+
+const rejectUnmappedTestStmt = "UPDATE unmapped_tests SET status = ? WHERE id = ?"
+
+const relinkUnmappedTestsStmt = "UPDATE tests SET area_id = ?, feature_id = ? WHERE unmapped_id = ?"
+
+func (cs CoverageStore) CreateUnmappedTestsTable() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := cs.db.ExecContext(ctx, createUnmappedTestStmt)
+	if err != nil {
+		log.Printf("Error %s when creating Unmapped Tests DB table\n", err)
+		return err
+	}
+	return nil
+}
+
+func (cs CoverageStore) InsertUnmappedTest(productId string, area string, feature string, seen time.Time) (int64, error) {
+	return cs.executeSql(insertUnmappedTestStmt, productId, area, feature, model.UnmappedPending, seen, seen)
+}
+
+// Counts another upload for the unmapped test
+func (cs CoverageStore) CountUnmappedTest(id int64, seen time.Time) (int64, error) {
+	return cs.executeSql(countUnmappedTestStmt, seen, id)
+}
+
+func (cs CoverageStore) RejectUnmappedTest(id int64) (int64, error) {
+	return cs.executeSql(rejectUnmappedTestStmt, model.UnmappedRejected, id)
+}
+
+// Accepts the unmapped test and links all its tests to the specified area and feature
+func (cs CoverageStore) AcceptUnmappedTest(id int64, areaId int64, featureId int64) error {
+	if _, err := cs.executeSql(acceptUnmappedTestStmt, model.UnmappedAccepted, areaId, featureId, id); err != nil {
+		return err
+	}
+	if _, err := cs.executeSql(relinkUnmappedTestsStmt, areaId, featureId, id); err != nil {
+		return fmt.Errorf("error linking tests: %w", err)
+	}
+	return cs.UpdateFirstUploads(areaId, featureId)
+}
+
+// Returns the unmapped test for the area and feature name of the product
+func (cs CoverageStore) GetUnmappedTestByName(productId string, area string, feature string) (model.UnmappedTest, error) {
+	tests, err := cs.getUnmappedTests(sq.Eq{"product_id": productId, "area": area, "feature": feature})
+	if err != nil {
+		return model.UnmappedTest{}, err
+	}
+	if len(tests) == 0 {
+		return model.UnmappedTest{}, sql.ErrNoRows
+	}
+	return tests[0], nil
+}
+
+func (cs CoverageStore) GetUnmappedTest(id string) (model.UnmappedTest, error) {
+	tests, err := cs.getUnmappedTests(sq.Eq{"id": id})
+	if err != nil {
+		return model.UnmappedTest{}, err
+	}
+	if len(tests) == 0 {
+		return model.UnmappedTest{}, sql.ErrNoRows
+	}
+	return tests[0], nil
+}
+
+// Get all unmapped tests of the product. If status is empty, all are returned.
+func (cs CoverageStore) GetUnmappedTests(productId string, status string) ([]model.UnmappedTest, error) {
+	where := sq.Eq{"product_id": productId}
+	if status != "" {
+		where["status"] = status
+	}
+	return cs.getUnmappedTests(where)
+}
+
+func (cs CoverageStore) getUnmappedTests(where sq.Eq) ([]model.UnmappedTest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	builder := sq.Select("id", "product_id", "area", "feature", "status", "COALESCE(area_id,0)", "COALESCE(feature_id,0)",
+		"occurrences", "first_seen", "last_seen").
+		From("unmapped_tests").
+		Where(where).
+		OrderBy("last_seen DESC")
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error %s when query context", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+	var tests = []model.UnmappedTest{}
+	for rows.Next() {
+		u := model.UnmappedTest{}
+		if err := rows.Scan(&u.Id, &u.ProductId, &u.Area, &u.Feature, &u.Status, &u.AreaId, &u.FeatureId,
+			&u.Occurrences, &u.FirstSeen, &u.LastSeen); err != nil {
+			log.Println(err)
+			return tests, err
+		}
+		tests = append(tests, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
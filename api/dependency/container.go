@@ -13,9 +13,12 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/TestAndWin/e2e-coverage/auth"
+	"github.com/TestAndWin/e2e-coverage/blob"
 	"github.com/TestAndWin/e2e-coverage/config"
+	"github.com/TestAndWin/e2e-coverage/coverage/cache"
 	"github.com/TestAndWin/e2e-coverage/coverage/repository"
 	"github.com/TestAndWin/e2e-coverage/db"
 	"github.com/TestAndWin/e2e-coverage/errors"
@@ -32,6 +35,12 @@ type Container struct {
 	coverageStore *repository.CoverageStore
 	userStore     *userRepo.UserStore
 
+	// Cache of the computed coverage
+	coverageCache *cache.Cache
+
+	// Store of the test attachments
+	blobStore blob.Store
+
 	// Auth
 	tokenManager *auth.TokenManager
 
@@ -42,6 +51,9 @@ type Container struct {
 	mu sync.Mutex
 }
 
+// Time after which a cached coverage expires, even if the product has not been changed
+const coverageCacheTTL = 10 * time.Minute
+
 // Create singleton instance
 var (
 	instance *Container
@@ -128,6 +140,34 @@ func (c *Container) GetCoverageStore() (*repository.CoverageStore, error) {
 	return c.coverageStore, nil
 }
 
+// GetCoverageCache returns the cache of the computed coverage
+func (c *Container) GetCoverageCache() *cache.Cache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.coverageCache == nil {
+		c.coverageCache = cache.New(coverageCacheTTL)
+	}
+
+	return c.coverageCache
+}
+
+// GetBlobStore returns the store of the test attachments, the files are stored in the attachment directory
+func (c *Container) GetBlobStore() (blob.Store, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.blobStore == nil {
+		store, err := blob.NewLocalStore(c.appConfig.AttachmentDir)
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Errorf("failed to create blob store: %w", err))
+		}
+		c.blobStore = store
+	}
+
+	return c.blobStore, nil
+}
+
 // GetUserStore returns the user repository
 func (c *Container) GetUserStore() (*userRepo.UserStore, error) {
 	c.mu.Lock()
@@ -1,5 +1,4 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -333,7 +332,7 @@ const docTemplate = `{
         },
         "/api/v1/coverage/areas/{id}/features": {
             "get": {
-                "description": "Get coverage for all area features. Only tests from the last 28 days are considered.",
+                "description": "Get coverage for all area features. Only tests of the coverage window are considered.",
                 "produces": [
                     "application/json"
                 ],
@@ -348,6 +347,36 @@ const docTemplate = `{
                         "name": "product",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -369,21 +398,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/coverage/{id}/areas": {
+        "/api/v1/coverage/attachments/{id}": {
             "get": {
-                "description": "Get coverage for all product areas. Only tests from the last 28 days are considered.",
+                "description": "Download the content of the attachment. Images and videos are shown in the browser, other files are downloaded.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "coverage"
+                    "attachment"
                 ],
-                "summary": "Get coverage for all product areas.",
+                "summary": "Download an attachment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "product",
+                        "description": "Attachment ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -392,81 +421,142 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Area"
-                            }
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/expl-tests": {
-            "post": {
-                "description": "Takes a exploratory test JSON and stores it in DB. Return saved JSON.",
+            },
+            "delete": {
+                "description": "Delete the attachment and its content",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "expl-test"
+                    "attachment"
                 ],
-                "summary": "Add a new expl test",
+                "summary": "Delete an attachment",
                 "parameters": [
                     {
-                        "description": "Expl Test JSON",
-                        "name": "expl-test",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ExplTest"
-                        }
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/model.ExplTest"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/expl-tests/area/{areaid}": {
-            "post": {
-                "description": "Get all exploratory tests for the specified area for the last 28 days",
+        "/api/v1/coverage/cache-stats": {
+            "get": {
+                "description": "Get the hits, misses and invalidations of the coverage cache since the start of the application",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "expl-test"
+                    "coverage"
                 ],
-                "summary": "Get all exploratory tests.",
+                "summary": "Get the statistics of the coverage cache",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/cache.Stats"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coverage/products/{id}/duration-regressions": {
+            "get": {
+                "description": "Compares the median duration of the passed results of every suite in the window with the one of the baseline\nwindow before it. Suites whose median grew by more than the duration regression percentage of the product are\nreturned, the highest increase first. Both windows need at least 3 passed results of a suite.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test"
+                ],
+                "summary": "Get the suites which became slower",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Area ID",
-                        "name": "areaid",
+                        "description": "Product ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component, default are all components",
+                        "name": "component",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the window, default 7",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the baseline window, default 28",
+                        "name": "baseline-days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -475,248 +565,343 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.ExplTest"
+                                "$ref": "#/definitions/model.DurationRegression"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/expl-tests/{id}": {
-            "delete": {
-                "description": "Delete an expl test",
+        "/api/v1/coverage/products/{id}/failure-clusters": {
+            "get": {
+                "description": "Get the failed test cases of the latest result of every test in the coverage window, grouped by their failure\nsignature. The signature is built from the failure message and the top of the stack outside of the test files,\nwithout numbers, ids and timestamps, so failures with the same root cause are one cluster. The clusters with the\nmost failures are first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "expl-test"
+                    "coverage"
                 ],
-                "summary": "Delete an expl test",
+                "summary": "Get the failure clusters of a product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Test ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of clusters, default 20",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.FailureCluster"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/features": {
-            "put": {
-                "description": "Takes a feature JSON and feature ID and updates it in DB. Return saved JSON.",
+        "/api/v1/coverage/products/{id}/flaky": {
+            "get": {
+                "description": "Get the flaky tests of the product, the highest flakiness score first. A test is flaky, if its status flipped\nbetween two results of the same commit or more than once within the latest results on the default branch.\nThe number of results is the flaky window of the product. The flips of these results are returned as well.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feature"
+                    "test"
                 ],
-                "summary": "Update a feature",
+                "summary": "Get the flaky tests of a product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Feature ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Feature JSON",
-                        "name": "feature",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Feature"
-                        }
+                        "type": "integer",
+                        "description": "Number of tests, default 20",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Feature"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TestFlakiness"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Takes a feature JSON and stores it in DB. Return saved JSON.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "feature"
-                ],
-                "summary": "Add a new feature to an area",
-                "parameters": [
-                    {
-                        "description": "Feature JSON",
-                        "name": "feature",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/model.Feature"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/model.Feature"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/features/{id}": {
-            "delete": {
-                "description": "Delete the product feature",
+        "/api/v1/coverage/products/{id}/runs": {
+            "get": {
+                "description": "Get the latest runs of a product with their CI metadata and the sum of their test results",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feature"
+                    "run"
                 ],
-                "summary": "Delete the product feature",
+                "summary": "Get the latest runs of a product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Feature ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of runs, default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/api/v1/products": {
-            "get": {
-                "description": "Get all products",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "product"
                 ],
-                "summary": "Get all products",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Product"
+                                "$ref": "#/definitions/model.Run"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Takes a product JSON and stores it in DB. Return saved JSON.",
+            }
+        },
+        "/api/v1/coverage/products/{id}/slow-suites": {
+            "get": {
+                "description": "Get the suites of the product with the highest median duration in the coverage window, the durations are in ms",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "test"
                 ],
-                "summary": "Add a new product",
+                "summary": "Get the slowest suites of a product",
                 "parameters": [
                     {
-                        "description": "Product JSON",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Product"
-                        }
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component, default are all components",
+                        "name": "component",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suites, default 20",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SuiteDuration"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/products/{id}": {
-            "put": {
-                "description": "Takes a product JSON and product ID and updates it in DB. Return saved JSON.",
+        "/api/v1/coverage/products/{id}/tests/durations": {
+            "get": {
+                "description": "Get the durations of the results of a suite in the coverage window, the oldest first. With a title the\ndurations of the test case with this full title are returned. The durations are in ms.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "test"
                 ],
-                "summary": "Update a product",
+                "summary": "Get the duration trend of a test",
                 "parameters": [
                     {
                         "type": "integer",
@@ -726,45 +911,98 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Product JSON",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Product"
-                        }
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suite name",
+                        "name": "suite",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name",
+                        "name": "file-name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full title of a test case",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DurationPoint"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete the product",
+            }
+        },
+        "/api/v1/coverage/products/{id}/tests/history": {
+            "get": {
+                "description": "Get all results of a test in the coverage window, the oldest first, together with its current streak,\nlast failure, last pass, failure rate and mean time between failures.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "test"
                 ],
-                "summary": "Delete the product",
+                "summary": "Get the run history of a test",
                 "parameters": [
                     {
                         "type": "integer",
@@ -772,38 +1010,95 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suite name",
+                        "name": "suite",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name",
+                        "name": "file-name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.TestHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/products/{id}/areas": {
+        "/api/v1/coverage/runs/{id}": {
             "get": {
-                "description": "Get all areas for the specified product",
+                "description": "Get a run with its CI metadata and the sum of its test results",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "area"
+                    "run"
                 ],
-                "summary": "Get all product areas",
+                "summary": "Get a run",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
+                        "type": "integer",
+                        "description": "Run ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -813,10 +1108,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Area"
-                            }
+                            "$ref": "#/definitions/model.Run"
                         }
                     },
                     "404": {
@@ -834,36 +1126,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tests": {
+        "/api/v1/coverage/runs/{id}/tests": {
             "get": {
-                "description": "Get all tests for the specified suite and filename.",
+                "description": "Get all test results uploaded for the run",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "test"
+                    "run"
                 ],
-                "summary": "Get all tests for the specified suite and filename.",
+                "summary": "Get all test results of a run",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Component name",
-                        "name": "component",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Suite name",
-                        "name": "suite",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "File name",
-                        "name": "file-name",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Run ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -880,160 +1158,141 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/tests/{id}": {
-            "delete": {
-                "description": "Delete all tests for the specified component, suite and file-name",
+        "/api/v1/coverage/tests/{id}/attachments": {
+            "get": {
+                "description": "Get the attachments of the test result, without their content",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "test"
+                    "attachment"
                 ],
-                "summary": "Delete all tests for the specified component, suite and file-name",
+                "summary": "Get the attachments of a test result",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Component name",
-                        "name": "component",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Suite name",
-                        "name": "suite",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "File name",
-                        "name": "file-name",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Test ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
-                "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/users": {
-            "get": {
-                "description": "Get all user",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Get all user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Attachment"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Takes a user JSON and stores it in DB. Return saved JSON.",
+                "description": "Upload files of a test result, e.g. screenshots and videos of a failed test, as multipart form with one or more\n\"file\" fields. The size of a file is limited by ATTACHMENT_MAX_SIZE_MB, attachments are deleted after\nATTACHMENT_RETENTION_DAYS.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "attachment"
                 ],
-                "summary": "Add a new user",
+                "summary": "Upload attachments of a test result",
                 "parameters": [
                     {
-                        "description": "User JSON",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
+                        "type": "integer",
+                        "description": "Test ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attachment",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Attachment"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/users/change-pwd": {
-            "put": {
-                "description": "Takes the NewPassword JSON and updates the password. Only possible for the current user to change his own password.",
+        "/api/v1/coverage/tests/{id}/cases": {
+            "get": {
+                "description": "Get all test cases of the uploaded test result with their state, duration and error.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "test"
                 ],
-                "summary": "Password Change",
+                "summary": "Get all test cases of a test.",
                 "parameters": [
                     {
-                        "description": "NewPassword JSON",
-                        "name": "newPassword",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.NewPassword"
-                        }
+                        "type": "integer",
+                        "description": "Test ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TestCase"
+                            }
                         }
                     },
                     "500": {
@@ -1045,25 +1304,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/generate-api-key": {
-            "post": {
-                "description": "Generate an API Key",
+        "/api/v1/coverage/{id}/areas": {
+            "get": {
+                "description": "Get coverage for all product areas. Only tests of the coverage window are considered.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "coverage"
+                ],
+                "summary": "Get coverage for all product areas.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Generate an API Key",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Area"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
@@ -1071,99 +1372,143 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{id}": {
-            "put": {
-                "description": "Takes a user JSON and updates the user.",
+        "/api/v1/coverage/{id}/snapshots": {
+            "post": {
+                "description": "Takes the coverage snapshots of the product for every day from the specified day until today.\nSnapshots are taken every hour in the background, this is needed only to fill the trend of past days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "coverage"
                 ],
-                "summary": "Change the role, name and password of a user",
+                "summary": "Take the coverage snapshots of a product",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User JSON",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
+                        "type": "string",
+                        "description": "First day (date), default is today",
+                        "name": "from",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.StandardResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete the user",
+            }
+        },
+        "/api/v1/coverage/{id}/trend": {
+            "get": {
+                "description": "Get the daily coverage snapshots of a product, an area or a feature. A snapshot is the coverage of the\ncoverage window and the default branch of the product at the end of the day (UTC).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "coverage"
                 ],
-                "summary": "Delete the user",
+                "summary": "Get the coverage trend of a product, an area or a feature",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Area ID, for the trend of an area",
+                        "name": "area-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature ID, for the trend of a feature",
+                        "name": "feature-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days, default 90",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CoverageSnapshot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/coverage/:id/upload-mocha-summary-report": {
+        "/api/v1/coverage/{id}/upload": {
             "post": {
-                "description": "Add test results of a mocha summary report.",
+                "description": "Add test results of a report. The format is taken from the format header or detected from the report. The CI metadata of the run can be sent as headers or together with the report in a JSON envelope {\"run\": {...}, \"report\": ...}.\nEither all test results are stored or none. In partial mode, the test results which could be stored are kept. The status of every test result is returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "mocha"
+                    "upload"
                 ],
-                "summary": "Add test results of a mocha summary report",
+                "summary": "Add test results of a report of any supported format",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1179,6 +1524,12 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Report format: mocha, junit, playwright or cucumber",
+                        "name": "format",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "Url of the detail test report",
@@ -1186,49 +1537,135 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "Mocha JSON",
-                        "name": "test",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of an existing run the results are added to",
+                        "name": "runId",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Commit SHA of the run",
+                        "name": "commitSha",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch of the run",
+                        "name": "branch",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Build number of the run",
+                        "name": "buildNumber",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Url of the CI job",
+                        "name": "jobUrl",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment the tests were executed against",
+                        "name": "environment",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User or event which triggered the run",
+                        "name": "triggeredBy",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the run (RFC 3339)",
+                        "name": "startedAt",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the run (RFC 3339)",
+                        "name": "endedAt",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep the stored test results if other test results fail",
+                        "name": "partial",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Test report",
+                        "name": "test",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
+                    },
+                    "400": {
                         "description": "Bad Request",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
                     }
                 }
             }
         },
-        "/coverage/components": {
-            "get": {
-                "description": "Get all components with their latest test run",
+        "/api/v1/expl-tests": {
+            "post": {
+                "description": "Takes a exploratory test JSON and stores it in DB. Return saved JSON.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "coverage"
+                    "expl-test"
+                ],
+                "summary": "Add a new expl test",
+                "parameters": [
+                    {
+                        "description": "Expl Test JSON",
+                        "name": "expl-test",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExplTest"
+                        }
+                    }
                 ],
-                "summary": "Get all components with their latest test run",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Test"
-                            }
+                            "$ref": "#/definitions/model.ExplTest"
                         }
                     },
                     "400": {
@@ -1236,27 +1673,51 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/coverage/features/:id/tests": {
-            "get": {
-                "description": "Get coverage for all tests of a feature for the last 28 days.",
+        "/api/v1/expl-tests/area/{areaid}": {
+            "post": {
+                "description": "Get all exploratory tests for the specified area in the coverage window of the product",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "coverage"
+                    "expl-test"
                 ],
-                "summary": "Get coverage for all tests of a feature.",
+                "summary": "Get all exploratory tests.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Feature ID",
-                        "name": "id",
+                        "description": "Area ID",
+                        "name": "areaid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1265,12 +1726,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Test"
+                                "$ref": "#/definitions/model.ExplTest"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -1278,176 +1739,2987 @@ const docTemplate = `{
                 }
             }
         },
-        "/coverage/products/:id/tests": {
-            "get": {
-                "description": "Get coverage for all tests of a product for the last 28 days.",
+        "/api/v1/expl-tests/{id}": {
+            "delete": {
+                "description": "Delete an expl test",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "coverage"
+                    "expl-test"
                 ],
-                "summary": "Get coverage for all tests of a product.",
+                "summary": "Delete an expl test",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Test ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/features": {
+            "put": {
+                "description": "Takes a feature JSON and feature ID and updates it in DB. Return saved JSON.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feature"
+                ],
+                "summary": "Update a feature",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Feature ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Feature JSON",
+                        "name": "feature",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Feature"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Test"
-                            }
+                            "$ref": "#/definitions/model.Feature"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Takes a feature JSON and stores it in DB. Return saved JSON.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feature"
+                ],
+                "summary": "Add a new feature to an area",
+                "parameters": [
+                    {
+                        "description": "Feature JSON",
+                        "name": "feature",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Feature"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Feature"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "errors.ErrorResponse": {
+        },
+        "/api/v1/features/{id}": {
+            "delete": {
+                "description": "Delete the product feature together with its tests and their attachments",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feature"
+                ],
+                "summary": "Delete the product feature",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Feature ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/mapping-rules": {
+            "post": {
+                "description": "Takes a mapping rule JSON and stores it in DB. Return saved JSON.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mapping-rule"
+                ],
+                "summary": "Add a new mapping rule to a product",
+                "parameters": [
+                    {
+                        "description": "Mapping rule JSON",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MappingRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.MappingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/mapping-rules/{id}": {
+            "put": {
+                "description": "Takes a mapping rule JSON and the rule ID and updates the rule in the DB.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mapping-rule"
+                ],
+                "summary": "Update a mapping rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mapping rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mapping rule JSON",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MappingRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MappingRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a mapping rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mapping-rule"
+                ],
+                "summary": "Delete a mapping rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mapping rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products": {
+            "get": {
+                "description": "Get all products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get all products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Product"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Takes a product JSON and stores it in DB. Return saved JSON.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Add a new product",
+                "parameters": [
+                    {
+                        "description": "Product JSON",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}": {
+            "put": {
+                "description": "Takes a product JSON and product ID and updates it in DB. Return saved JSON.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product JSON",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the product together with its test results, attachments, runs and audit log. A product with areas\ncannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Delete the product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/areas": {
+            "get": {
+                "description": "Get all areas for the specified product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "area"
+                ],
+                "summary": "Get all product areas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Area"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/audit-log": {
+            "get": {
+                "description": "Get the latest entries of the audit log of the product, the latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Get the audit log of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries, default 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/mapping-rules": {
+            "get": {
+                "description": "Get all mapping rules of the specified product, ordered by priority",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mapping-rule"
+                ],
+                "summary": "Get all mapping rules of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MappingRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/quarantines": {
+            "get": {
+                "description": "Get the quarantines of the product which have not expired, the ones expiring first first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-quarantine"
+                ],
+                "summary": "Get the quarantined tests of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TestQuarantine"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Takes a test quarantine JSON with the component, suite and file of the test, a reason, an owner and the expiry.\nUntil the quarantine expires, the failures of the test are not part of the failures of its area and feature,\nthey are reported as quarantined. Quarantining a quarantined test again replaces its reason, owner and expiry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-quarantine"
+                ],
+                "summary": "Quarantine a test",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Test quarantine JSON",
+                        "name": "quarantine",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TestQuarantine"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.TestQuarantine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/settings": {
+            "get": {
+                "description": "Get the settings of a product, e.g. the strict mapping mode",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get the settings of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductSettings"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Takes a product settings JSON and the product ID and updates the settings in DB. Settings which are\nnot part of the JSON are not changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update the settings of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product settings JSON",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/test-aliases": {
+            "get": {
+                "description": "Get all test aliases of the specified product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-alias"
+                ],
+                "summary": "Get all test aliases of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TestAlias"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Takes a test alias JSON with the old and new suite and file. The test results of the old suite and file are then part of the history and coverage of the new one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-alias"
+                ],
+                "summary": "Declare that a test was renamed or moved",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Test alias JSON",
+                        "name": "alias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TestAlias"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.TestAlias"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/test-assignments": {
+            "get": {
+                "description": "Get all test assignments of the specified product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-assignment"
+                ],
+                "summary": "Get all test assignments of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TestAssignment"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/tests/assign": {
+            "put": {
+                "description": "Assigns all tests of a component, suite and file without area and feature to the specified area and feature. Future uploads of these tests which cannot be mapped are assigned as well.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-assignment"
+                ],
+                "summary": "Assign tests to an area and feature",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Test assignment JSON",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TestAssignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TestAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/tests/assign-bulk": {
+            "put": {
+                "description": "Assigns all tests without area and feature matching the filter to the specified area and feature. An assignment is stored for every component, suite and file, so future uploads are assigned as well.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-assignment"
+                ],
+                "summary": "Assign all tests matching a filter to an area and feature",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Filter with area and feature ID",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TestAssignmentFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TestAssignment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/unmapped-tests": {
+            "get": {
+                "description": "Get the unknown area and feature names uploaded in strict mapping mode. By default only the pending ones are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unmapped-test"
+                ],
+                "summary": "Get the unmapped tests of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, accepted, rejected or all",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UnmappedTest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quarantines/{id}": {
+            "delete": {
+                "description": "Delete a test quarantine, the failures of the test are part of the failures of its area and feature again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-quarantine"
+                ],
+                "summary": "Release a test from quarantine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Test quarantine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/test-aliases/{id}": {
+            "delete": {
+                "description": "Delete a test alias, the old suite and file are a separate test again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-alias"
+                ],
+                "summary": "Delete a test alias",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Test alias ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/test-assignments/{id}": {
+            "delete": {
+                "description": "Delete a test assignment. Tests already assigned keep their area and feature.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test-assignment"
+                ],
+                "summary": "Delete a test assignment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Test assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tests": {
+            "get": {
+                "description": "Get all tests for the specified suite and filename.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test"
+                ],
+                "summary": "Get all tests for the specified suite and filename.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suite name",
+                        "name": "suite",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name",
+                        "name": "file-name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days, default 28",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the window",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Test"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tests/{id}": {
+            "delete": {
+                "description": "Delete all tests for the specified component, suite and file-name together with their attachments",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "test"
+                ],
+                "summary": "Delete all tests for the specified component, suite and file-name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suite name",
+                        "name": "suite",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name",
+                        "name": "file-name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/unmapped-tests/{id}/accept": {
+            "post": {
+                "description": "Maps the unmapped test to the area and feature in the body. Without body, area and feature are created using the uploaded names. All stored tests are linked to the area and feature.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unmapped-test"
+                ],
+                "summary": "Accept an unmapped test",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unmapped test ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Area and feature ID",
+                        "name": "mapping",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnmappedTestMapping"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnmappedTest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/unmapped-tests/{id}/reject": {
+            "post": {
+                "description": "Rejects the unmapped test. Tests with these area and feature names are stored without area and feature.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unmapped-test"
+                ],
+                "summary": "Reject an unmapped test",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unmapped test ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnmappedTest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "Get all user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get all user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Takes a user JSON and stores it in DB. Return saved JSON.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Add a new user",
+                "parameters": [
+                    {
+                        "description": "User JSON",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/change-pwd": {
+            "put": {
+                "description": "Takes the NewPassword JSON and updates the password. Only possible for the current user to change his own password.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Password Change",
+                "parameters": [
+                    {
+                        "description": "NewPassword JSON",
+                        "name": "newPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NewPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/generate-api-key": {
+            "post": {
+                "description": "Generate an API Key",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Generate an API Key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "put": {
+                "description": "Takes a user JSON and updates the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change the role, name and password of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User JSON",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/:id/upload-cucumber-report": {
+            "post": {
+                "description": "Add test results of a Cucumber JSON report. Every Gherkin feature is stored as one test result, area and feature are taken from the @area: and @feature: tags or the feature name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cucumber"
+                ],
+                "summary": "Add test results of a Cucumber JSON report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Api Key",
+                        "name": "apiKey",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Url of the detail test report",
+                        "name": "testReportUrl",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Cucumber JSON",
+                        "name": "test",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/:id/upload-junit-report": {
+            "post": {
+                "description": "Add test results of a JUnit XML report. Every testsuite element is stored as one test result.",
+                "consumes": [
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "junit"
+                ],
+                "summary": "Add test results of a JUnit XML report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Api Key",
+                        "name": "apiKey",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Url of the detail test report",
+                        "name": "testReportUrl",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component name",
+                        "name": "component",
+                        "in": "header"
+                    },
+                    {
+                        "description": "JUnit XML",
+                        "name": "test",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/:id/upload-mocha-summary-report": {
+            "post": {
+                "description": "Add test results of a mocha summary report.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mocha"
+                ],
+                "summary": "Add test results of a mocha summary report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Api Key",
+                        "name": "apiKey",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Url of the detail test report",
+                        "name": "testReportUrl",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Mocha JSON",
+                        "name": "test",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/:id/upload-playwright-report": {
+            "post": {
+                "description": "Add test results of a Playwright JSON report. Every project is stored as a separate component.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "playwright"
+                ],
+                "summary": "Add test results of a Playwright JSON report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Api Key",
+                        "name": "apiKey",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Url of the detail test report",
+                        "name": "testReportUrl",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component name, the project name is appended",
+                        "name": "component",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Playwright JSON",
+                        "name": "test",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.UploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/components": {
+            "get": {
+                "description": "Get all components with their latest test run",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coverage"
+                ],
+                "summary": "Get all components with their latest test run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Branch",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days, default are all test runs",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Test"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/features/:id/tests": {
+            "get": {
+                "description": "Get coverage for all tests of a feature in the coverage window.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coverage"
+                ],
+                "summary": "Get coverage for all tests of a feature.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Feature ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Test"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/coverage/products/:id/tests": {
+            "get": {
+                "description": "Get coverage for all tests of a product in the coverage window. The list is paged, if page or page-size is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "coverage"
+                ],
+                "summary": "Get coverage for all tests of a product.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch, default is the default branch of the product. Empty for all branches.",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment",
+                        "name": "environment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days of the coverage window, default is the coverage window of the product",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the coverage window (date or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the coverage window (date or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page, starting with 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tests per page, default 50, max 500",
+                        "name": "page-size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Component",
+                        "name": "component",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Area ID",
+                        "name": "area-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature ID",
+                        "name": "feature-id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only tests without area and feature",
+                        "name": "unassigned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "failing, passing or flaky",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the suite or file name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, last-run or failure-rate, default is component, suite and file",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc or desc, default asc for name and desc otherwise",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Test"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "cache.Stats": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer"
+                },
+                "hit-rate": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "invalidations": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "integer"
+                }
+            }
+        },
+        "errors.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.Area": {
+            "type": "object",
+            "properties": {
+                "expl-rating": {
+                    "type": "number"
+                },
+                "expl-tests": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "first-total": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passes": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "quarantined": {
+                    "description": "Failures of quarantined tests, they are not part of the failures",
+                    "type": "integer"
+                },
+                "retry-passes": {
+                    "description": "Tests which passed after a retry, they are part of the passes as well",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Attachment": {
+            "type": "object",
+            "properties": {
+                "content-type": {
+                    "type": "string"
+                },
+                "created-at": {
+                    "type": "string"
+                },
+                "created-by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "test-id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "created-at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entity-id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CoverageSnapshot": {
+            "type": "object",
+            "properties": {
+                "area-id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "feature-id": {
+                    "type": "integer"
+                },
+                "first-total": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "passes": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "retry-passes": {
+                    "description": "Tests which passed after a retry, they are part of the passes as well",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Credentials": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DurationPoint": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "test-id": {
+                    "type": "integer"
+                },
+                "test-run": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DurationRegression": {
+            "type": "object",
+            "properties": {
+                "baseline-median": {
+                    "type": "integer"
+                },
+                "baseline-runs": {
+                    "type": "integer"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "current-median": {
+                    "type": "integer"
+                },
+                "current-runs": {
+                    "type": "integer"
+                },
+                "file-name": {
+                    "type": "string"
+                },
+                "increase-pct": {
+                    "type": "number"
+                },
+                "suite": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ExplTest": {
+            "type": "object",
+            "properties": {
+                "area-id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "test-run": {
+                    "type": "string"
+                },
+                "tester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FailedTest": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "type": "string"
+                },
+                "file-name": {
+                    "type": "string"
+                },
+                "suite": {
+                    "type": "string"
+                },
+                "test-case-id": {
+                    "type": "integer"
+                },
+                "test-id": {
+                    "type": "integer"
+                },
+                "test-run": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FailureCluster": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer"
+                },
+                "first-seen": {
+                    "type": "string"
+                },
+                "last-seen": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message of the latest failure, and its message without numbers, ids and timestamps",
+                    "type": "string"
+                },
+                "normalized-message": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "stack": {
+                    "type": "string"
+                },
+                "suites": {
+                    "type": "integer"
+                },
+                "tests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FailedTest"
+                    }
+                }
+            }
+        },
+        "model.Feature": {
+            "type": "object",
+            "properties": {
+                "area-id": {
+                    "type": "integer"
+                },
+                "business-value": {
+                    "type": "string"
+                },
+                "documentation": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "first-total": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passes": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "quarantined": {
+                    "description": "Failures of quarantined tests, they are not part of the failures",
+                    "type": "integer"
+                },
+                "retry-passes": {
+                    "description": "Tests which passed after a retry, they are part of the passes as well",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "tests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Test"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MappingRule": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "type": "string"
+                },
+                "feature": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NewPassword": {
+            "type": "object",
+            "properties": {
+                "new-password": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Product": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductSettings": {
+            "type": "object",
+            "properties": {
+                "coverage-days": {
+                    "description": "Number of days of the coverage window",
+                    "type": "integer"
+                },
+                "default-branch": {
+                    "description": "Coverage is calculated for this branch, unless another branch is requested",
+                    "type": "string"
+                },
+                "duration-regression-pct": {
+                    "description": "Increase of the median duration of a suite in percent, which is reported as regression",
+                    "type": "integer"
+                },
+                "flaky-window": {
+                    "description": "Number of the latest results of a test used to detect flaky tests",
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "strict-mapping": {
+                    "description": "Unknown areas and features are not created automatically but added to the triage queue",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.Run": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "type": "string"
+                },
+                "build-number": {
+                    "type": "string"
+                },
+                "commit-sha": {
+                    "type": "string"
+                },
+                "ended-at": {
+                    "type": "string"
+                },
+                "environment": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job-url": {
+                    "type": "string"
+                },
+                "passes": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "retry-passes": {
+                    "description": "Tests which passed after a retry, they are part of the passes as well",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "started-at": {
+                    "type": "string"
+                },
+                "tests": {
+                    "description": "Sum of all test results of the run",
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "triggered-by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SuiteDuration": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "type": "string"
+                },
+                "file-name": {
+                    "type": "string"
+                },
+                "last-run": {
+                    "type": "string"
+                },
+                "latest": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "integer"
+                },
+                "median": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "suite": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Test": {
+            "type": "object",
+            "properties": {
+                "area-id": {
+                    "type": "integer"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "failed-test-runs": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "feature-id": {
+                    "type": "integer"
+                },
+                "file-name": {
+                    "type": "string"
+                },
+                "first-total": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is-first": {
+                    "type": "boolean"
+                },
+                "passes": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "quarantined": {
+                    "description": "Failures of quarantined tests, they are not part of the failures",
+                    "type": "integer"
+                },
+                "retried-test-runs": {
+                    "description": "Number of results in the window with tests which passed after a retry",
+                    "type": "integer"
+                },
+                "retry-passes": {
+                    "description": "Tests which passed after a retry, they are part of the passes as well",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "suite": {
+                    "type": "string"
+                },
+                "test-run": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total-test-runs": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TestAlias": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new-file": {
+                    "type": "string"
+                },
+                "new-suite": {
+                    "type": "string"
+                },
+                "old-file": {
+                    "type": "string"
+                },
+                "old-suite": {
+                    "type": "string"
+                },
+                "product-id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TestAssignment": {
+            "type": "object",
+            "properties": {
+                "area-id": {
+                    "type": "integer"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "feature-id": {
+                    "type": "integer"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "suite": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TestAssignmentFilter": {
             "type": "object",
             "properties": {
-                "code": {
+                "area-id": {
+                    "type": "integer"
+                },
+                "component": {
                     "type": "string"
                 },
-                "details": {
+                "feature-id": {
+                    "type": "integer"
+                },
+                "file": {
                     "type": "string"
                 },
-                "message": {
+                "suite": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TestCase": {
+            "type": "object",
+            "properties": {
+                "diff": {
                     "type": "string"
                 },
-                "status": {
+                "duration": {
                     "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "error-message": {
+                    "type": "string"
+                },
+                "full-title": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "description": "Failures with the same signature very likely have the same root cause",
+                    "type": "string"
+                },
+                "stack": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "test-id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "model.Area": {
+        "model.TestFlakiness": {
             "type": "object",
             "properties": {
-                "expl-rating": {
-                    "type": "number"
+                "component": {
+                    "type": "string"
                 },
-                "expl-tests": {
-                    "type": "integer"
+                "file-name": {
+                    "type": "string"
                 },
-                "failures": {
-                    "type": "integer"
+                "flaky": {
+                    "type": "boolean"
                 },
-                "first-total": {
+                "flips": {
                     "type": "integer"
                 },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TestFlip"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "last-flip": {
                     "type": "string"
                 },
-                "passes": {
+                "product-id": {
                     "type": "integer"
                 },
-                "pending": {
+                "retried-runs": {
+                    "description": "Number of results with tests which passed after a retry, they count as passed for the flips",
                     "type": "integer"
                 },
-                "product-id": {
+                "runs": {
+                    "description": "Number of passed and failed results, skipped results are not considered",
                     "type": "integer"
                 },
-                "skipped": {
+                "same-commit-flips": {
                     "type": "integer"
                 },
-                "total": {
-                    "type": "integer"
+                "score": {
+                    "description": "Between 0 and 1, flips on the same commit count double, results which passed after a retry count as well",
+                    "type": "number"
+                },
+                "suite": {
+                    "type": "string"
                 }
             }
         },
-        "model.Credentials": {
+        "model.TestFlip": {
             "type": "object",
             "properties": {
-                "email": {
+                "commit-sha": {
                     "type": "string"
                 },
-                "password": {
+                "from": {
+                    "type": "string"
+                },
+                "same-commit": {
+                    "type": "boolean"
+                },
+                "test-id": {
+                    "type": "integer"
+                },
+                "test-run": {
+                    "type": "string"
+                },
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "model.ExplTest": {
+        "model.TestHistory": {
             "type": "object",
             "properties": {
-                "area-id": {
-                    "type": "integer"
+                "component": {
+                    "type": "string"
                 },
-                "id": {
+                "current-streak": {
+                    "description": "Number of the latest results with the same status as the latest result",
                     "type": "integer"
                 },
-                "rating": {
-                    "type": "integer"
+                "failure-rate": {
+                    "description": "Failed results divided by all results",
+                    "type": "number"
                 },
-                "summary": {
+                "file-name": {
                     "type": "string"
                 },
-                "test-run": {
+                "last-failure": {
                     "type": "string"
                 },
-                "tester": {
-                    "type": "integer"
+                "last-pass": {
+                    "type": "string"
+                },
+                "mtbf-hours": {
+                    "description": "Mean time between two failure onsets, i.e. a failed result whose previous result did not fail.\n0 if there are less than two onsets.",
+                    "type": "number"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TestHistoryEntry"
+                    }
+                },
+                "streak-status": {
+                    "type": "string"
+                },
+                "suite": {
+                    "type": "string"
                 }
             }
         },
-        "model.Feature": {
+        "model.TestHistoryEntry": {
             "type": "object",
             "properties": {
-                "area-id": {
-                    "type": "integer"
+                "branch": {
+                    "type": "string"
                 },
-                "business-value": {
+                "commit-sha": {
                     "type": "string"
                 },
-                "documentation": {
+                "duration": {
+                    "type": "integer"
+                },
+                "environment": {
                     "type": "string"
                 },
                 "failures": {
                     "type": "integer"
                 },
-                "first-total": {
+                "passes": {
                     "type": "integer"
                 },
-                "id": {
+                "pending": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "passes": {
+                "retry-passes": {
                     "type": "integer"
                 },
-                "pending": {
+                "run-id": {
                     "type": "integer"
                 },
                 "skipped": {
                     "type": "integer"
                 },
-                "tests": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Test"
-                    }
+                "status": {
+                    "type": "string"
+                },
+                "test-id": {
+                    "type": "integer"
+                },
+                "test-run": {
+                    "type": "string"
                 },
                 "total": {
                     "type": "integer"
@@ -1457,85 +4729,170 @@ const docTemplate = `{
                 }
             }
         },
-        "model.NewPassword": {
+        "model.TestQuarantine": {
             "type": "object",
             "properties": {
-                "new-password": {
+                "component": {
                     "type": "string"
                 },
-                "password": {
+                "created-at": {
                     "type": "string"
-                }
-            }
-        },
-        "model.Product": {
-            "type": "object",
-            "properties": {
+                },
+                "created-by": {
+                    "type": "string"
+                },
+                "expires-at": {
+                    "type": "string"
+                },
+                "file-name": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "owner": {
+                    "type": "string"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "suite": {
                     "type": "string"
                 }
             }
         },
-        "model.Test": {
+        "model.UnmappedTest": {
             "type": "object",
             "properties": {
+                "area": {
+                    "type": "string"
+                },
                 "area-id": {
                     "type": "integer"
                 },
-                "component": {
+                "feature": {
                     "type": "string"
                 },
-                "failed-test-runs": {
+                "feature-id": {
                     "type": "integer"
                 },
-                "failures": {
-                    "type": "integer"
+                "first-seen": {
+                    "type": "string"
                 },
-                "feature-id": {
+                "id": {
                     "type": "integer"
                 },
-                "file-name": {
+                "last-seen": {
                     "type": "string"
                 },
-                "first-total": {
+                "occurrences": {
+                    "type": "integer"
+                },
+                "product-id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UnmappedTestMapping": {
+            "type": "object",
+            "properties": {
+                "area-id": {
                     "type": "integer"
                 },
+                "feature-id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UploadEntity": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "integer"
                 },
-                "is-first": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "passes": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UploadResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
                     "type": "integer"
                 },
-                "pending": {
+                "duplicates": {
                     "type": "integer"
                 },
-                "product-id": {
+                "failed": {
                     "type": "integer"
                 },
-                "skipped": {
+                "partial": {
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UploadResult"
+                    }
+                },
+                "run-id": {
+                    "description": "ID of the run of the upload, 0 if no test result was stored",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UploadResult": {
+            "type": "object",
+            "properties": {
+                "area-id": {
+                    "description": "Area and feature the test result is mapped to, 0 if it could not be mapped",
                     "type": "integer"
                 },
-                "suite": {
+                "component": {
                     "type": "string"
                 },
-                "test-run": {
+                "created-entities": {
+                    "description": "Entities created while storing the test result",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UploadEntity"
+                    }
+                },
+                "error": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "error-code": {
+                    "type": "string"
                 },
-                "total-test-runs": {
+                "feature-id": {
                     "type": "integer"
                 },
-                "url": {
+                "file-name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "suite": {
                     "type": "string"
                 },
+                "test-id": {
+                    "description": "The IDs are only set if the test result is stored. The ID of the test result is needed to upload attachments.",
+                    "type": "integer"
+                },
+                "unmapped-id": {
+                    "type": "integer"
+                },
                 "uuid": {
                     "type": "string"
                 }
@@ -1590,6 +4947,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "API for e2e-coverage",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -66,6 +66,16 @@ func ResponseWithDataAndMeta(c *gin.Context, statusCode int, data interface{}, m
 	})
 }
 
+// ResponseWithFailure returns a standardized unsuccessful response with data and message, e.g. the status of
+// every item of a request which failed in parts
+func ResponseWithFailure(c *gin.Context, statusCode int, data interface{}, message string) {
+	c.JSON(statusCode, StandardResponse{
+		Success: false,
+		Data:    data,
+		Message: message,
+	})
+}
+
 // Common HTTP status helpers
 
 // OK sends a 200 OK response with data
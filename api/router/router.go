@@ -77,6 +77,8 @@ func setupAPIRoutes(router *gin.Engine) {
 		v1.GET("/products", usercontroller.AuthUser(model.MAINTAINER), controller.GetProducts)
 		v1.PUT("/products/:id", usercontroller.AuthUser(model.MAINTAINER), controller.UpdateProduct)
 		v1.DELETE("/products/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteProduct)
+		v1.GET("/products/:id/settings", usercontroller.AuthUser(model.MAINTAINER), controller.GetProductSettings)
+		v1.PUT("/products/:id/settings", usercontroller.AuthUser(model.MAINTAINER), controller.UpdateProductSettings)
 
 		v1.POST("/areas", usercontroller.AuthUser(model.MAINTAINER), controller.AddArea)
 		v1.GET("/products/:id/areas", usercontroller.AuthUser(model.MAINTAINER), controller.GetProductAreas)
@@ -88,6 +90,28 @@ func setupAPIRoutes(router *gin.Engine) {
 		v1.PUT("/features/:id", usercontroller.AuthUser(model.MAINTAINER), controller.UpdateFeature)
 		v1.DELETE("/features/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteFeature)
 
+		v1.POST("/mapping-rules", usercontroller.AuthUser(model.MAINTAINER), controller.AddMappingRule)
+		v1.GET("/products/:id/mapping-rules", usercontroller.AuthUser(model.MAINTAINER), controller.GetProductMappingRules)
+		v1.PUT("/mapping-rules/:id", usercontroller.AuthUser(model.MAINTAINER), controller.UpdateMappingRule)
+		v1.DELETE("/mapping-rules/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteMappingRule)
+
+		v1.GET("/products/:id/unmapped-tests", usercontroller.AuthUser(model.MAINTAINER), controller.GetProductUnmappedTests)
+		v1.POST("/unmapped-tests/:id/accept", usercontroller.AuthUser(model.MAINTAINER), controller.AcceptUnmappedTest)
+		v1.POST("/unmapped-tests/:id/reject", usercontroller.AuthUser(model.MAINTAINER), controller.RejectUnmappedTest)
+
+		v1.PUT("/products/:id/tests/assign", usercontroller.AuthUser(model.MAINTAINER), controller.AssignTests)
+		v1.PUT("/products/:id/tests/assign-bulk", usercontroller.AuthUser(model.MAINTAINER), controller.AssignTestsBulk)
+		v1.GET("/products/:id/test-assignments", usercontroller.AuthUser(model.MAINTAINER), controller.GetProductTestAssignments)
+		v1.DELETE("/test-assignments/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteTestAssignment)
+
+		v1.POST("/products/:id/test-aliases", usercontroller.AuthUser(model.MAINTAINER), controller.AddTestAlias)
+		v1.GET("/products/:id/test-aliases", usercontroller.AuthUser(model.MAINTAINER), controller.GetProductTestAliases)
+		v1.DELETE("/test-aliases/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteTestAlias)
+		v1.POST("/products/:id/quarantines", usercontroller.AuthUser(model.MAINTAINER), controller.AddTestQuarantine)
+		v1.GET("/products/:id/quarantines", usercontroller.AuthUser(model.TESTER), controller.GetProductTestQuarantines)
+		v1.DELETE("/quarantines/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteTestQuarantine)
+		v1.GET("/products/:id/audit-log", usercontroller.AuthUser(model.MAINTAINER), controller.GetAuditLog)
+
 		v1.GET("/tests", usercontroller.AuthUser(model.MAINTAINER), controller.GetAllTestForSuiteFile)
 		v1.DELETE("/tests", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteTests)
 
@@ -97,12 +121,33 @@ func setupAPIRoutes(router *gin.Engine) {
 		v1.DELETE("/expl-tests/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteExplTest)
 
 		// Test Coverage
+		v1.POST("/coverage/:id/upload", usercontroller.AuthApi(), controller.UploadReport)
 		v1.POST("/coverage/:id/upload-mocha-summary-report", usercontroller.AuthApi(), controller.UploadMochaSummaryReport)
+		v1.POST("/coverage/:id/upload-junit-report", usercontroller.AuthApi(), controller.UploadJUnitReport)
+		v1.POST("/coverage/:id/upload-playwright-report", usercontroller.AuthApi(), controller.UploadPlaywrightReport)
+		v1.POST("/coverage/:id/upload-cucumber-report", usercontroller.AuthApi(), controller.UploadCucumberReport)
 		v1.GET("/coverage/:id/areas", usercontroller.AuthUser(model.TESTER), controller.GetAreaCoverage)
 		v1.GET("/coverage/components", usercontroller.AuthUser(model.TESTER), controller.GetComponents)
 		v1.GET("/coverage/areas/:id/features", usercontroller.AuthUser(model.TESTER), controller.GetFeatureCoverage)
 		v1.GET("/coverage/features/:id/tests", usercontroller.AuthUser(model.TESTER), controller.GetTestsCoverage)
 		v1.GET("/coverage/products/:id/tests", usercontroller.AuthUser(model.TESTER), controller.GetProductTestsCoverage)
+		v1.GET("/coverage/products/:id/tests/history", usercontroller.AuthUser(model.TESTER), controller.GetTestHistory)
+		v1.GET("/coverage/products/:id/flaky", usercontroller.AuthUser(model.TESTER), controller.GetFlakyTests)
+		v1.GET("/coverage/products/:id/failure-clusters", usercontroller.AuthUser(model.TESTER), controller.GetFailureClusters)
+		v1.GET("/coverage/products/:id/slow-suites", usercontroller.AuthUser(model.TESTER), controller.GetSlowSuites)
+		v1.GET("/coverage/products/:id/tests/durations", usercontroller.AuthUser(model.TESTER), controller.GetDurationTrend)
+		v1.GET("/coverage/products/:id/duration-regressions", usercontroller.AuthUser(model.TESTER), controller.GetDurationRegressions)
+		v1.GET("/coverage/tests/:id/cases", usercontroller.AuthUser(model.TESTER), controller.GetTestCases)
+		v1.POST("/coverage/tests/:id/attachments", usercontroller.AuthApi(), controller.UploadAttachments)
+		v1.GET("/coverage/tests/:id/attachments", usercontroller.AuthUser(model.TESTER), controller.GetTestAttachments)
+		v1.GET("/coverage/attachments/:id", usercontroller.AuthUser(model.TESTER), controller.DownloadAttachment)
+		v1.DELETE("/coverage/attachments/:id", usercontroller.AuthUser(model.MAINTAINER), controller.DeleteAttachment)
+		v1.GET("/coverage/products/:id/runs", usercontroller.AuthUser(model.TESTER), controller.GetProductRuns)
+		v1.GET("/coverage/runs/:id", usercontroller.AuthUser(model.TESTER), controller.GetRun)
+		v1.GET("/coverage/runs/:id/tests", usercontroller.AuthUser(model.TESTER), controller.GetRunTests)
+		v1.GET("/coverage/:id/trend", usercontroller.AuthUser(model.TESTER), controller.GetCoverageTrend)
+		v1.POST("/coverage/:id/snapshots", usercontroller.AuthUser(model.MAINTAINER), controller.TakeCoverageSnapshots)
+		v1.GET("/coverage/cache-stats", usercontroller.AuthUser(model.ADMIN), controller.GetCoverageCacheStats)
 
 		// Authentication endpoints
 		v1.POST("/auth/login", usercontroller.Login)